@@ -0,0 +1,69 @@
+package fsm
+
+import "github.com/enetx/g"
+
+// approvalKeyPrefix namespaces the Meta entries used to track quorum
+// approvals, keeping them out of the way of user data.
+const approvalKeyPrefix = ReservedMetaPrefix + "approvals:"
+
+// RequireApprovals adds a transition from -> event -> to that only commits
+// after the event has been triggered by n distinct actors, as recorded with
+// WithActor. Each trigger before quorum records the actor and fails with
+// *ErrInvalidTransition; the trigger that completes the quorum commits the
+// transition and clears the recorded approvals. Collected approvals live in
+// Meta under the "fsm:approvals:" prefix and therefore survive
+// Marshal/Unmarshal, so multi-sign-off workflows work across restarts.
+func (f *FSM) RequireApprovals(from State, event Event, to State, n int) *FSM {
+	key := approvalKey(from, event)
+
+	return f.TransitionWhen(from, event, to, func(ctx *Context) bool {
+		if f.actor == "" {
+			return false
+		}
+
+		actors := approvalActors(ctx.Meta.Get(key).UnwrapOrDefault())
+		if !actors.Contains(f.actor) {
+			actors = append(actors, f.actor)
+		}
+
+		if actors.Len() < g.Int(n) {
+			ctx.Meta.Insert(key, actors)
+			return false
+		}
+
+		ctx.Meta.Remove(key)
+
+		return true
+	})
+}
+
+// Approvals returns the distinct actors that have approved the given pending
+// transition so far.
+func (f *FSM) Approvals(from State, event Event) g.Slice[g.String] {
+	return approvalActors(f.ctx.Meta.Get(approvalKey(from, event)).UnwrapOrDefault())
+}
+
+// approvalKey builds the Meta key that tracks approvals for one transition.
+func approvalKey(from State, event Event) g.String {
+	return approvalKeyPrefix + g.String(from) + ":" + g.String(event)
+}
+
+// approvalActors normalizes the stored approval list, accepting both the
+// in-memory slice and the []any form produced by a JSON round-trip.
+func approvalActors(value any) g.Slice[g.String] {
+	switch actors := value.(type) {
+	case g.Slice[g.String]:
+		return actors
+	case []any:
+		collected := g.NewSlice[g.String](0, g.Int(len(actors)))
+		for _, actor := range actors {
+			if s, ok := actor.(string); ok {
+				collected.Push(g.String(s))
+			}
+		}
+
+		return collected
+	default:
+		return nil
+	}
+}
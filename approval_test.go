@@ -0,0 +1,50 @@
+package fsm_test
+
+import (
+	"testing"
+
+	. "github.com/enetx/fsm"
+)
+
+func TestFSM_RequireApprovals(t *testing.T) {
+	testFSM := New("draft").RequireApprovals("draft", "publish", "published", 2)
+
+	testFSM.WithActor("alice")
+	assertError(t, testFSM.Trigger("publish"))
+	assertEqual(t, testFSM.Current(), State("draft"))
+	assertEqual(t, testFSM.Approvals("draft", "publish").Len(), 1)
+
+	// The same actor approving twice does not advance the quorum.
+	assertError(t, testFSM.Trigger("publish"))
+	assertEqual(t, testFSM.Approvals("draft", "publish").Len(), 1)
+
+	testFSM.WithActor("bob")
+	assertNoError(t, testFSM.Trigger("publish"))
+	assertEqual(t, testFSM.Current(), State("published"))
+	assertTrue(t, testFSM.Approvals("draft", "publish").IsEmpty())
+}
+
+func TestFSM_RequireApprovals_Serialized(t *testing.T) {
+	testFSM := New("draft").RequireApprovals("draft", "publish", "published", 2)
+
+	testFSM.WithActor("alice")
+	assertError(t, testFSM.Trigger("publish"))
+
+	data, err := testFSM.MarshalJSON()
+	assertNoError(t, err)
+
+	restored := New("draft").RequireApprovals("draft", "publish", "published", 2)
+	assertNoError(t, restored.UnmarshalJSON(data))
+	assertEqual(t, restored.Approvals("draft", "publish").Len(), 1)
+
+	restored.WithActor("bob")
+	assertNoError(t, restored.Trigger("publish"))
+	assertEqual(t, restored.Current(), State("published"))
+}
+
+func TestFSM_RequireApprovals_NoActor(t *testing.T) {
+	testFSM := New("draft").RequireApprovals("draft", "publish", "published", 1)
+
+	assertError(t, testFSM.Trigger("publish"))
+	assertTrue(t, testFSM.Approvals("draft", "publish").IsEmpty())
+}
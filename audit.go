@@ -0,0 +1,170 @@
+package fsm
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"reflect"
+	"time"
+
+	"github.com/enetx/g"
+)
+
+// AuditEntry is a structured, timestamped record of one committed transition.
+type AuditEntry struct {
+	Seq      uint64               `json:"seq"`
+	ID       g.String             `json:"id,omitempty"`
+	Actor    g.String             `json:"actor,omitempty"`
+	From     State                `json:"from"`
+	To       State                `json:"to"`
+	Event    Event                `json:"event"`
+	Time     time.Time            `json:"time"`
+	DataDiff g.Map[g.String, any] `json:"data_diff,omitempty"`
+}
+
+// AuditSink receives an audit entry for every committed transition.
+// Implementations can ship entries to files, syslog, or cloud logging.
+type AuditSink interface {
+	Write(AuditEntry) error
+}
+
+// WriterAuditSink writes audit entries to an io.Writer as JSON lines.
+// Use NewWriterAuditSink(os.Stdout) for stdout logging.
+type WriterAuditSink struct {
+	w io.Writer
+}
+
+// NewWriterAuditSink returns an AuditSink emitting JSON lines to w.
+func NewWriterAuditSink(w io.Writer) *WriterAuditSink { return &WriterAuditSink{w: w} }
+
+// Write implements the AuditSink interface.
+func (s *WriterAuditSink) Write(entry AuditEntry) error { return json.NewEncoder(s.w).Encode(entry) }
+
+// FileAuditSink appends audit entries to a file as JSON lines, rotating the
+// file to "<path>.1" once it exceeds maxSize bytes.
+type FileAuditSink struct {
+	path    g.String
+	maxSize int64
+}
+
+// NewFileAuditSink returns an AuditSink writing to the file at path.
+// When the file grows beyond maxSize bytes it is rotated to "<path>.1",
+// replacing any previous rotation. A maxSize of 0 disables rotation.
+func NewFileAuditSink(path g.String, maxSize int64) *FileAuditSink {
+	return &FileAuditSink{path: path, maxSize: maxSize}
+}
+
+// Write implements the AuditSink interface.
+func (s *FileAuditSink) Write(entry AuditEntry) error {
+	if s.maxSize > 0 {
+		if info, err := os.Stat(s.path.Std()); err == nil && info.Size() >= s.maxSize {
+			if err := os.Rename(s.path.Std(), s.path.Std()+".1"); err != nil {
+				return err
+			}
+		}
+	}
+
+	file, err := os.OpenFile(s.path.Std(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return json.NewEncoder(file).Encode(entry)
+}
+
+// WithAuditLog enables in-memory audit recording. Every committed transition
+// is appended to a log retrievable via AuditLog and exportable as JSON lines
+// via WriteAuditLog. Timestamps come from the FSM's clock.
+func (f *FSM) WithAuditLog() *FSM {
+	f.auditEnabled = true
+	return f
+}
+
+// WithAuditSink registers a sink that receives an entry for every committed
+// transition, including the instance ID, actor, and a shallow diff of the
+// context Data map. Sink errors are ignored; auditing is best-effort and
+// must not abort a transition that has already been committed.
+func (f *FSM) WithAuditSink(sink AuditSink) *FSM {
+	f.auditSinks.Push(sink)
+	return f
+}
+
+// StreamAuditTo streams every committed transition to w as a JSON line, as
+// it happens. It is shorthand for WithAuditSink(NewWriterAuditSink(w)).
+func (f *FSM) StreamAuditTo(w io.Writer) *FSM { return f.WithAuditSink(NewWriterAuditSink(w)) }
+
+// AuditLog returns a copy of the recorded audit entries.
+// It is empty unless WithAuditLog was called.
+func (f *FSM) AuditLog() g.Slice[AuditEntry] { return f.auditLog.Clone() }
+
+// WriteAuditLog writes the recorded audit entries to w in JSON lines format,
+// one entry per line, suitable for shipping to a logging pipeline.
+func (f *FSM) WriteAuditLog(w io.Writer) error {
+	enc := json.NewEncoder(w)
+
+	for entry := range f.auditLog.Iter() {
+		if err := enc.Encode(entry); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// auditActive reports whether any audit destination is configured.
+func (f *FSM) auditActive() bool { return f.auditEnabled || !f.auditSinks.IsEmpty() }
+
+// recordAudit appends an audit entry for a committed transition. before is
+// the Data map captured at the start of the trigger, used to compute the
+// data diff; it may be nil when no sink is attached.
+func (f *FSM) recordAudit(from, to State, event Event, before g.Map[g.String, any]) {
+	if !f.auditActive() {
+		return
+	}
+
+	f.auditSeq++
+
+	entry := AuditEntry{
+		Seq:      f.auditSeq,
+		ID:       f.id,
+		Actor:    f.actor,
+		From:     from,
+		To:       to,
+		Event:    event,
+		Time:     f.clock.Now(),
+		DataDiff: diffData(before, f.ctx.Data.Iter().Collect()),
+	}
+
+	if f.auditEnabled {
+		f.auditLog.Push(entry)
+	}
+
+	for sink := range f.auditSinks.Iter() {
+		_ = sink.Write(entry)
+	}
+}
+
+// diffData returns a shallow diff between two Data maps: added and changed
+// keys map to their new value, removed keys map to nil.
+func diffData(before, after g.Map[g.String, any]) g.Map[g.String, any] {
+	diff := g.NewMap[g.String, any]()
+
+	for key, value := range after.Iter() {
+		if old := before.Get(key); old.IsNone() || !reflect.DeepEqual(old.Some(), value) {
+			diff.Insert(key, value)
+		}
+	}
+
+	for key := range before.Iter() {
+		if !after.Contains(key) {
+			diff.Insert(key, nil)
+		}
+	}
+
+	if diff.IsEmpty() {
+		return nil
+	}
+
+	return diff
+}
@@ -0,0 +1,60 @@
+package fsm_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/enetx/fsm"
+)
+
+func TestFSM_AuditLog(t *testing.T) {
+	testFSM := New("idle").
+		WithAuditLog().
+		Transition("idle", "start", "running").
+		Transition("running", "stop", "idle")
+
+	assertNoError(t, testFSM.Trigger("start"))
+	assertNoError(t, testFSM.Trigger("stop"))
+
+	log := testFSM.AuditLog()
+	assertEqual(t, log.Len(), 2)
+	assertEqual(t, log[0].From, State("idle"))
+	assertEqual(t, log[0].To, State("running"))
+	assertEqual(t, log[0].Event, Event("start"))
+	assertEqual(t, log[1].Seq, uint64(2))
+
+	var sb strings.Builder
+	assertNoError(t, testFSM.WriteAuditLog(&sb))
+	assertEqual(t, len(strings.Split(strings.TrimSpace(sb.String()), "\n")), 2)
+}
+
+func TestFSM_StreamAuditTo(t *testing.T) {
+	var sb strings.Builder
+
+	testFSM := New("idle").
+		StreamAuditTo(&sb).
+		Transition("idle", "start", "running")
+
+	assertNoError(t, testFSM.Trigger("start"))
+	assertTrue(t, strings.Contains(sb.String(), `"from":"idle"`))
+	assertTrue(t, strings.Contains(sb.String(), `"event":"start"`))
+}
+
+func TestFSM_AuditSink_IDActorAndDiff(t *testing.T) {
+	var sb strings.Builder
+
+	testFSM := New("idle").
+		WithID("order-7").
+		WithActor("alice").
+		WithAuditSink(NewWriterAuditSink(&sb)).
+		Transition("idle", "start", "running").
+		OnEnter("running", func(ctx *Context) error {
+			ctx.Data.Insert("started", true)
+			return nil
+		})
+
+	assertNoError(t, testFSM.Trigger("start"))
+	assertTrue(t, strings.Contains(sb.String(), `"id":"order-7"`))
+	assertTrue(t, strings.Contains(sb.String(), `"actor":"alice"`))
+	assertTrue(t, strings.Contains(sb.String(), `"data_diff":{"started":true}`))
+}
@@ -0,0 +1,39 @@
+package fsm
+
+// Base is an embeddable state machine holder for domain structs. A domain
+// type embeds Base, initializes it once with InitFSM, and exposes
+// domain-level methods (order.Submit, article.Publish) that delegate to
+// Fire, keeping the machine's wiring out of the domain type's public API:
+//
+//	type Order struct {
+//		fsm.Base
+//	}
+//
+//	func NewOrder() *Order {
+//		o := &Order{}
+//		o.InitFSM(fsm.New("pending").Transition("pending", "submit", "submitted"))
+//		return o
+//	}
+//
+//	func (o *Order) Submit() error { return o.Fire("submit") }
+//
+// Base is not concurrent-safe; guard the embedding struct itself if it is
+// shared across goroutines.
+type Base struct {
+	machine *FSM
+}
+
+// InitFSM attaches the machine driving this value. It must be called before
+// any other Base method, typically in the domain type's constructor.
+func (b *Base) InitFSM(machine *FSM) { b.machine = machine }
+
+// Fire triggers an event on the embedded machine. Domain methods wrap it to
+// give transitions business-level names.
+func (b *Base) Fire(event Event, input ...any) error { return b.machine.Trigger(event, input...) }
+
+// State returns the embedded machine's current state.
+func (b *Base) State() State { return b.machine.Current() }
+
+// FSM exposes the embedded machine for wiring callbacks or persistence. It
+// is an escape hatch; day-to-day code should go through domain methods.
+func (b *Base) FSM() *FSM { return b.machine }
@@ -0,0 +1,38 @@
+package fsm_test
+
+import (
+	"testing"
+
+	"github.com/enetx/fsm"
+)
+
+type order struct {
+	fsm.Base
+}
+
+func newOrder() *order {
+	o := &order{}
+	o.InitFSM(fsm.New("pending").
+		Transition("pending", "submit", "submitted").
+		Transition("submitted", "approve", "approved"))
+
+	return o
+}
+
+func (o *order) Submit() error  { return o.Fire("submit") }
+func (o *order) Approve() error { return o.Fire("approve") }
+
+func TestBase_Embedding(t *testing.T) {
+	o := newOrder()
+
+	assertEqual(t, o.State(), fsm.State("pending"))
+	assertNoError(t, o.Submit())
+	assertEqual(t, o.State(), fsm.State("submitted"))
+
+	// Invalid domain operations surface the machine's transition errors.
+	assertError(t, o.Submit())
+
+	assertNoError(t, o.Approve())
+	assertEqual(t, o.State(), fsm.State("approved"))
+	assertEqual(t, o.FSM().History().Len(), 3)
+}
@@ -0,0 +1,94 @@
+// Package boltstore provides a bbolt-backed fsm.Store, giving FSM instances
+// durability across restarts without any external infrastructure. It is
+// aimed at single-binary deployments such as edge devices and CLIs.
+package boltstore
+
+import (
+	"github.com/enetx/fsm"
+	"github.com/enetx/g"
+	"go.etcd.io/bbolt"
+)
+
+// bucket is the bbolt bucket holding all serialized instances.
+var bucket = []byte("fsm")
+
+// Interface compliance check.
+var _ fsm.Store = (*Store)(nil)
+
+// Store is an fsm.Store backed by a bbolt database file.
+type Store struct {
+	db *bbolt.DB
+}
+
+// Open opens (or creates) the bbolt database at path and returns a Store.
+func Open(path g.String) (*Store, error) {
+	db, err := bbolt.Open(path.Std(), 0o600, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(bucket)
+		return err
+	}); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Save writes the serialized instance under id.
+func (s *Store) Save(id g.String, data []byte) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(bucket).Put(id.Bytes(), data)
+	})
+}
+
+// Load reads the serialized instance stored under id. It returns
+// *fsm.ErrInstanceNotFound when the id is unknown.
+func (s *Store) Load(id g.String) ([]byte, error) {
+	var data []byte
+
+	if err := s.db.View(func(tx *bbolt.Tx) error {
+		stored := tx.Bucket(bucket).Get(id.Bytes())
+		if stored == nil {
+			return &fsm.ErrInstanceNotFound{ID: id}
+		}
+
+		data = append(data, stored...)
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// Delete removes the instance stored under id. Deleting an unknown id is
+// not an error.
+func (s *Store) Delete(id g.String) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(bucket).Delete(id.Bytes())
+	})
+}
+
+// List returns the IDs of all stored instances.
+func (s *Store) List() (g.Slice[g.String], error) {
+	var ids g.Slice[g.String]
+
+	if err := s.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(bucket).ForEach(func(key, _ []byte) error {
+			ids.Push(g.String(key))
+			return nil
+		})
+	}); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// Close closes the underlying database.
+func (s *Store) Close() error { return s.db.Close() }
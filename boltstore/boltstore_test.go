@@ -0,0 +1,46 @@
+package boltstore_test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/enetx/fsm"
+	"github.com/enetx/fsm/boltstore"
+	"github.com/enetx/g"
+)
+
+func TestStore_SaveLoadDelete(t *testing.T) {
+	store, err := boltstore.Open(g.String(filepath.Join(t.TempDir(), "fsm.db")))
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer store.Close()
+
+	if err := store.Save("order-1", []byte(`{"current":"paid"}`)); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	data, err := store.Load("order-1")
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	if string(data) != `{"current":"paid"}` {
+		t.Fatalf("unexpected data: %s", data)
+	}
+
+	ids, err := store.List()
+	if err != nil || ids.Len() != 1 {
+		t.Fatalf("list: %v %v", ids, err)
+	}
+
+	if err := store.Delete("order-1"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	var notFound *fsm.ErrInstanceNotFound
+	if _, err := store.Load("order-1"); !errors.As(err, &notFound) {
+		t.Fatalf("expected ErrInstanceNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,46 @@
+package fsm
+
+import "github.com/enetx/g"
+
+// Checkpoint snapshots the full machine (state, history, context data) under
+// a name. Checkpoints are serialized with the instance and can be restored
+// later with Rollback, e.g. to try a branch of a wizard and revert if the
+// user declines. Taking a checkpoint with an existing name overwrites it.
+func (f *FSM) Checkpoint(name g.String) *FSM {
+	f.checkpoints.Insert(name, f.snapshot())
+	return f
+}
+
+// Rollback restores the machine to a previously taken named checkpoint.
+// The checkpoint is kept, so the same point can be rolled back to again.
+func (f *FSM) Rollback(name g.String) error {
+	checkpoint := f.checkpoints.Get(name)
+	if checkpoint.IsNone() {
+		return &ErrUnknownCheckpoint{Name: name}
+	}
+
+	return f.restore(checkpoint.Some())
+}
+
+// Checkpoints returns the names of all stored checkpoints.
+func (f *FSM) Checkpoints() g.Slice[g.String] { return f.checkpoints.Keys() }
+
+// RemoveCheckpoint deletes a named checkpoint. It reports whether the
+// checkpoint existed.
+func (f *FSM) RemoveCheckpoint(name g.String) bool { return f.checkpoints.Remove(name).IsSome() }
+
+// Checkpoint is the thread-safe version of FSM.Checkpoint.
+func (sf *SyncFSM) Checkpoint(name g.String) {
+	sf.mu.Lock()
+	defer sf.mu.Unlock()
+
+	sf.fsm.Checkpoint(name)
+}
+
+// Rollback is the thread-safe version of FSM.Rollback.
+func (sf *SyncFSM) Rollback(name g.String) error {
+	sf.mu.Lock()
+	defer sf.mu.Unlock()
+
+	return sf.fsm.Rollback(name)
+}
@@ -0,0 +1,47 @@
+package fsm_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/enetx/fsm"
+)
+
+func TestFSM_CheckpointRollback(t *testing.T) {
+	testFSM := New("step1").
+		Transition("step1", "next", "step2").
+		Transition("step2", "next", "step3")
+
+	assertNoError(t, testFSM.Trigger("next"))
+	testFSM.Context().Data.Insert("answer", 42)
+	testFSM.Checkpoint("before_branch")
+
+	assertNoError(t, testFSM.Trigger("next"))
+	testFSM.Context().Data.Insert("answer", 99)
+
+	assertNoError(t, testFSM.Rollback("before_branch"))
+	assertEqual(t, testFSM.Current(), State("step2"))
+	assertEqual(t, testFSM.Context().Data.Get("answer").Some().(int), 42)
+
+	assertError(t, testFSM.Rollback("missing"))
+}
+
+func TestFSM_CheckpointSerialization(t *testing.T) {
+	build := func() *FSM {
+		return New("step1").
+			Transition("step1", "next", "step2")
+	}
+
+	source := build()
+	source.Checkpoint("start")
+	assertNoError(t, source.Trigger("next"))
+
+	data, err := json.Marshal(source)
+	assertNoError(t, err)
+
+	restored := build()
+	assertNoError(t, json.Unmarshal(data, restored))
+	assertEqual(t, restored.Current(), State("step2"))
+	assertNoError(t, restored.Rollback("start"))
+	assertEqual(t, restored.Current(), State("step1"))
+}
@@ -0,0 +1,28 @@
+package fsm
+
+import "time"
+
+// Clock abstracts the time source used by the FSM. All time-dependent
+// features (timestamps, throttling, scheduling) read time through the
+// machine's clock, so tests can inject a fake clock and advance it manually
+// instead of sleeping for real.
+type Clock interface {
+	Now() time.Time
+}
+
+// realClock is the default Clock, backed by the standard time package.
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+// WithClock replaces the FSM's time source. It should be called before the
+// machine is used; the default is the system clock.
+func (f *FSM) WithClock(c Clock) *FSM {
+	f.clock = c
+	f.ctx.clock = c
+
+	return f
+}
+
+// Clock returns the FSM's current time source.
+func (f *FSM) Clock() Clock { return f.clock }
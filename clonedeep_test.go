@@ -0,0 +1,29 @@
+package fsm_test
+
+import (
+	"testing"
+
+	. "github.com/enetx/fsm"
+)
+
+func TestFSM_CloneDeep_Isolated(t *testing.T) {
+	template := New("pending").Transition("pending", "pay", "paid")
+
+	cloned := template.CloneDeep().Transition("pending", "cancel", "canceled")
+
+	assertNoError(t, cloned.Trigger("cancel"))
+	assertEqual(t, cloned.Current(), State("canceled"))
+
+	// The template does not pick up transitions added to the deep clone.
+	assertError(t, template.Trigger("cancel"))
+	assertEqual(t, template.Current(), State("pending"))
+}
+
+func TestFSM_Clone_SharesDefinition(t *testing.T) {
+	template := New("pending").Transition("pending", "pay", "paid")
+
+	template.Clone().Transition("pending", "cancel", "canceled")
+
+	// Documented sharing behavior: plain clones mutate the template.
+	assertNoError(t, template.Trigger("cancel"))
+}
@@ -0,0 +1,59 @@
+package fsm
+
+import "github.com/enetx/g"
+
+// ValueCodec converts a context value to and from its serialized form, e.g.
+// a time.Time as RFC3339 or a Money type as a string. Encode must return a
+// JSON-serializable representation; Decode receives the value as produced by
+// the JSON decoder and returns the in-memory form.
+type ValueCodec struct {
+	Encode func(any) (any, error)
+	Decode func(any) (any, error)
+}
+
+// WithKeyCodec registers a codec applied to the Data or Meta entry with the
+// given key during Marshal and Unmarshal, so custom types survive
+// persistence without wrapper types. Codecs are part of the definition and
+// are shared by clones.
+func (f *FSM) WithKeyCodec(key g.String, codec ValueCodec) *FSM {
+	f.codecs.Insert(key, codec)
+	return f
+}
+
+// encodeCodecs applies registered codecs to the values they cover.
+func (f *FSM) encodeCodecs(values g.Map[g.String, any]) error {
+	for key, codec := range f.codecs.Iter() {
+		value := values.Get(key)
+		if value.IsNone() || codec.Encode == nil {
+			continue
+		}
+
+		encoded, err := codec.Encode(value.Some())
+		if err != nil {
+			return err
+		}
+
+		values.Insert(key, encoded)
+	}
+
+	return nil
+}
+
+// decodeCodecs reverses encodeCodecs on a decoded snapshot.
+func (f *FSM) decodeCodecs(values g.Map[g.String, any]) error {
+	for key, codec := range f.codecs.Iter() {
+		value := values.Get(key)
+		if value.IsNone() || codec.Decode == nil {
+			continue
+		}
+
+		decoded, err := codec.Decode(value.Some())
+		if err != nil {
+			return err
+		}
+
+		values.Insert(key, decoded)
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package fsm_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/enetx/fsm"
+)
+
+func TestFSM_KeyCodec(t *testing.T) {
+	timeCodec := ValueCodec{
+		Encode: func(v any) (any, error) {
+			stamp, ok := v.(time.Time)
+			if !ok {
+				return nil, errors.New("expected time.Time")
+			}
+
+			return stamp.Format(time.RFC3339), nil
+		},
+		Decode: func(v any) (any, error) {
+			encoded, ok := v.(string)
+			if !ok {
+				return nil, errors.New("expected string")
+			}
+
+			return time.Parse(time.RFC3339, encoded)
+		},
+	}
+
+	deadline := time.Date(2026, 8, 1, 12, 0, 0, 0, time.UTC)
+
+	source := newOrderFSM().WithKeyCodec("deadline", timeCodec)
+	source.Context().Data.Insert("deadline", deadline)
+
+	data, err := source.MarshalJSON()
+	assertNoError(t, err)
+
+	restored := newOrderFSM().WithKeyCodec("deadline", timeCodec)
+	assertNoError(t, restored.UnmarshalJSON(data))
+	assertTrue(t, restored.Context().Data.Get("deadline").Some().(time.Time).Equal(deadline))
+}
@@ -0,0 +1,74 @@
+package fsm
+
+import "github.com/enetx/g"
+
+// ConfigProvider supplies runtime configuration to guards and callbacks.
+// Implementations are expected to be refreshable — backed by a feature-flag
+// service, a config file watcher, or similar — so configuration changes
+// affect transition decisions without rebuilding machines.
+type ConfigProvider interface {
+	GetBool(key g.String) bool
+	GetString(key g.String) g.Option[g.String]
+	GetInt(key g.String) g.Option[g.Int]
+}
+
+// MapConfig is a ConfigProvider backed by a concurrent-safe map, suitable
+// for tests and for applications that push config updates themselves.
+type MapConfig struct {
+	values *g.MapSafe[g.String, any]
+}
+
+// NewMapConfig returns an empty, refreshable MapConfig.
+func NewMapConfig() *MapConfig {
+	return &MapConfig{values: g.NewMapSafe[g.String, any]()}
+}
+
+// Set stores or updates a configuration value.
+func (c *MapConfig) Set(key g.String, value any) *MapConfig {
+	c.values.Insert(key, value)
+	return c
+}
+
+// GetBool implements the ConfigProvider interface. Missing or non-bool
+// values read as false.
+func (c *MapConfig) GetBool(key g.String) bool {
+	if value, ok := c.values.Get(key).UnwrapOrDefault().(bool); ok {
+		return value
+	}
+
+	return false
+}
+
+// GetString implements the ConfigProvider interface.
+func (c *MapConfig) GetString(key g.String) g.Option[g.String] {
+	switch value := c.values.Get(key).UnwrapOrDefault().(type) {
+	case g.String:
+		return g.Some(value)
+	case string:
+		return g.Some(g.String(value))
+	default:
+		return g.None[g.String]()
+	}
+}
+
+// GetInt implements the ConfigProvider interface.
+func (c *MapConfig) GetInt(key g.String) g.Option[g.Int] {
+	switch value := c.values.Get(key).UnwrapOrDefault().(type) {
+	case g.Int:
+		return g.Some(value)
+	case int:
+		return g.Some(g.Int(value))
+	default:
+		return g.None[g.Int]()
+	}
+}
+
+// WithConfig injects a ConfigProvider into the machine's Context, where
+// guards can read it via ctx.Config. The provider is shared by clones, so a
+// single refreshable source can drive a whole fleet of instances.
+func (f *FSM) WithConfig(provider ConfigProvider) *FSM {
+	f.config = provider
+	f.ctx.Config = provider
+
+	return f
+}
@@ -0,0 +1,47 @@
+package fsm_test
+
+import (
+	"testing"
+
+	. "github.com/enetx/fsm"
+)
+
+func TestFSM_WithConfig_GuardReadsFlags(t *testing.T) {
+	config := NewMapConfig()
+
+	testFSM := New("cart").
+		TransitionWhen("cart", "checkout", "done", func(ctx *Context) bool {
+			return ctx.Config.GetBool("allow_express_checkout")
+		}).
+		WithConfig(config)
+
+	assertError(t, testFSM.Trigger("checkout"))
+
+	// Flipping the flag at runtime changes the decision without rebuilding.
+	config.Set("allow_express_checkout", true)
+	assertNoError(t, testFSM.Trigger("checkout"))
+	assertEqual(t, testFSM.Current(), State("done"))
+}
+
+func TestFSM_WithConfig_SurvivesCloneAndReset(t *testing.T) {
+	config := NewMapConfig().Set("flag", true)
+
+	testFSM := New("a").WithConfig(config)
+
+	assertTrue(t, testFSM.Clone().Context().Config.GetBool("flag"))
+
+	testFSM.Reset()
+	assertTrue(t, testFSM.Context().Config.GetBool("flag"))
+}
+
+func TestMapConfig_Getters(t *testing.T) {
+	config := NewMapConfig().
+		Set("name", "fsm").
+		Set("limit", 3)
+
+	assertEqual(t, config.GetString("name").Some(), "fsm")
+	assertEqual(t, config.GetInt("limit").Some(), 3)
+	assertTrue(t, config.GetString("missing").IsNone())
+	assertTrue(t, config.GetInt("missing").IsNone())
+	assertFalse(t, config.GetBool("missing"))
+}
@@ -1,23 +1,79 @@
 package fsm
 
-import "github.com/enetx/g"
+import (
+	"time"
+
+	"github.com/enetx/g"
+)
 
 // Context holds FSM state, input, persistent and temporary data.
 // Data is for long-lived values (e.g. user ID, settings) and is serialized.
 // Meta is for ephemeral metadata (e.g. timestamps, counters) and is also serialized.
 // Input holds data specific to the current trigger event and is NOT serialized.
 // State holds the state for which a callback is being executed.
+// Config exposes the ConfigProvider injected with WithConfig, or nil.
 type Context struct {
-	State State
-	Input any
-	Data  *g.MapSafe[g.String, any]
-	Meta  *g.MapSafe[g.String, any]
+	State  State
+	Input  any
+	Data   *g.MapSafe[g.String, any]
+	Meta   *g.MapSafe[g.String, any]
+	Config ConfigProvider
+
+	clock Clock
 }
 
-func newContext(initial State) *Context {
+func newContext(initial State, clock Clock) *Context {
 	return &Context{
 		State: initial,
 		Data:  g.NewMapSafe[g.String, any](),
 		Meta:  g.NewMapSafe[g.String, any](),
+		clock: clock,
 	}
 }
+
+// expiringValue wraps a context value stored with a TTL. It is serialized
+// with its deadline so the expiry survives a restore.
+type expiringValue struct {
+	Value    any       `json:"value"`
+	Deadline time.Time `json:"deadline"`
+}
+
+// SetWithTTL stores a Data value that expires after ttl. Expiry is enforced
+// lazily: the value disappears when read through Get or when the machine is
+// marshaled after the deadline. Reading it directly from Data returns the
+// internal wrapper, so prefer Get for values stored this way.
+func (c *Context) SetWithTTL(key g.String, value any, ttl time.Duration) {
+	c.Data.Insert(key, expiringValue{Value: value, Deadline: c.clock.Now().Add(ttl)})
+}
+
+// SetMetaWithTTL stores a Meta value that expires after ttl, with the same
+// lazy-expiry semantics as SetWithTTL.
+func (c *Context) SetMetaWithTTL(key g.String, value any, ttl time.Duration) {
+	c.Meta.Insert(key, expiringValue{Value: value, Deadline: c.clock.Now().Add(ttl)})
+}
+
+// Get reads a Data value, transparently unwrapping TTL entries. Expired
+// entries are removed and reported as None.
+func (c *Context) Get(key g.String) g.Option[any] { return c.getFrom(c.Data, key) }
+
+// GetMeta reads a Meta value, transparently unwrapping TTL entries. Expired
+// entries are removed and reported as None.
+func (c *Context) GetMeta(key g.String) g.Option[any] { return c.getFrom(c.Meta, key) }
+
+func (c *Context) getFrom(section *g.MapSafe[g.String, any], key g.String) g.Option[any] {
+	value := section.Get(key)
+	if value.IsNone() {
+		return value
+	}
+
+	if expiring, ok := value.Some().(expiringValue); ok {
+		if c.clock.Now().After(expiring.Deadline) {
+			section.Remove(key)
+			return g.None[any]()
+		}
+
+		return g.Some(expiring.Value)
+	}
+
+	return value
+}
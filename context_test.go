@@ -0,0 +1,43 @@
+package fsm_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/enetx/fsm"
+)
+
+func TestContext_SetWithTTL(t *testing.T) {
+	clock := &stubClock{now: time.Now()}
+	testFSM := New("a").WithClock(clock).Transition("a", "go", "b")
+
+	ctx := testFSM.Context()
+	ctx.SetWithTTL("otp", "123456", time.Minute)
+	assertEqual(t, ctx.Get("otp").Some().(string), "123456")
+
+	clock.now = clock.now.Add(2 * time.Minute)
+	assertTrue(t, ctx.Get("otp").IsNone())
+	assertTrue(t, ctx.Data.Get("otp").IsNone())
+}
+
+func TestContext_TTLPrunedAtMarshal(t *testing.T) {
+	clock := &stubClock{now: time.Now()}
+	testFSM := New("a").WithClock(clock).Transition("a", "go", "b")
+
+	ctx := testFSM.Context()
+	ctx.SetWithTTL("temp", 1, time.Minute)
+	ctx.SetMetaWithTTL("counter", 2, time.Hour)
+	clock.now = clock.now.Add(30 * time.Minute)
+
+	data, err := testFSM.MarshalJSON()
+	assertNoError(t, err)
+
+	restored := New("a").WithClock(clock).Transition("a", "go", "b")
+	assertNoError(t, restored.UnmarshalJSON(data))
+	assertTrue(t, restored.Context().Get("temp").IsNone())
+	assertEqual(t, restored.Context().GetMeta("counter").Some().(float64), float64(2))
+
+	// The revived entry keeps expiring after the restore.
+	clock.now = clock.now.Add(time.Hour)
+	assertTrue(t, restored.Context().GetMeta("counter").IsNone())
+}
@@ -0,0 +1,56 @@
+package fsm
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/enetx/g"
+)
+
+// SnapshotDiff describes what changed between two serialized snapshots.
+// StateChanged reports whether the current state differs; HistoryDelta holds
+// the states appended to (or, when negative trimming occurred, removed from)
+// the history; DataDiff and MetaDiff follow the audit-log convention: added
+// and changed keys map to their new value, removed keys map to nil.
+type SnapshotDiff struct {
+	StateChanged bool                 `json:"state_changed"`
+	From         State                `json:"from"`
+	To           State                `json:"to"`
+	VersionDelta int64                `json:"version_delta"`
+	HistoryDelta g.Slice[State]       `json:"history_delta,omitempty"`
+	DataDiff     g.Map[g.String, any] `json:"data_diff,omitempty"`
+	MetaDiff     g.Map[g.String, any] `json:"meta_diff,omitempty"`
+}
+
+// DiffSnapshots compares two snapshots produced by MarshalJSON and reports
+// the state change, the history delta, and per-key Data/Meta changes. It is
+// aimed at support tooling and tests that assert exactly what a trigger
+// changed.
+func DiffSnapshots(a, b []byte) (SnapshotDiff, error) {
+	var before, after FSMState
+
+	if err := json.Unmarshal(a, &before); err != nil {
+		return SnapshotDiff{}, fmt.Errorf("failed to unmarshal first snapshot: %w", err)
+	}
+
+	if err := json.Unmarshal(b, &after); err != nil {
+		return SnapshotDiff{}, fmt.Errorf("failed to unmarshal second snapshot: %w", err)
+	}
+
+	diff := SnapshotDiff{
+		StateChanged: before.Current != after.Current,
+		From:         before.Current,
+		To:           after.Current,
+		VersionDelta: int64(after.Version) - int64(before.Version),
+		DataDiff:     diffData(before.Data, after.Data),
+		MetaDiff:     diffData(before.Meta, after.Meta),
+	}
+
+	if after.History.Len() > before.History.Len() {
+		diff.HistoryDelta = after.History[before.History.Len():].Clone()
+	} else if before.History.Len() > after.History.Len() {
+		diff.HistoryDelta = before.History[after.History.Len():].Clone()
+	}
+
+	return diff, nil
+}
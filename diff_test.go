@@ -0,0 +1,46 @@
+package fsm_test
+
+import (
+	"testing"
+
+	. "github.com/enetx/fsm"
+)
+
+func TestDiffSnapshots(t *testing.T) {
+	testFSM := newOrderFSM()
+
+	before, err := testFSM.MarshalJSON()
+	assertNoError(t, err)
+
+	testFSM.Context().Data.Insert("amount", 100)
+	assertNoError(t, testFSM.Trigger("pay"))
+
+	after, err := testFSM.MarshalJSON()
+	assertNoError(t, err)
+
+	diff, err := DiffSnapshots(before, after)
+	assertNoError(t, err)
+
+	assertTrue(t, diff.StateChanged)
+	assertEqual(t, diff.From, State("pending"))
+	assertEqual(t, diff.To, State("paid"))
+	assertEqual(t, diff.VersionDelta, int64(1))
+	assertEqual(t, diff.HistoryDelta.Len(), 1)
+	assertEqual(t, diff.HistoryDelta[0], State("paid"))
+	assertEqual(t, diff.DataDiff.Get("amount").Some(), any(float64(100)))
+}
+
+func TestDiffSnapshots_NoChange(t *testing.T) {
+	testFSM := newOrderFSM()
+
+	data, err := testFSM.MarshalJSON()
+	assertNoError(t, err)
+
+	diff, err := DiffSnapshots(data, data)
+	assertNoError(t, err)
+
+	assertFalse(t, diff.StateChanged)
+	assertTrue(t, diff.HistoryDelta.IsEmpty())
+	assertTrue(t, diff.DataDiff.IsEmpty())
+	assertTrue(t, diff.MetaDiff.IsEmpty())
+}
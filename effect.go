@@ -0,0 +1,59 @@
+package fsm
+
+import "github.com/enetx/g"
+
+// EffectSemantics controls how a side effect registered with Effect behaves
+// when its state is entered again, e.g. after a crash and restore.
+type EffectSemantics int
+
+const (
+	// AtMostOnce records completion before the effect runs: even if the
+	// process crashes mid-effect, the effect is never executed twice.
+	AtMostOnce EffectSemantics = iota
+	// AtLeastOnce records completion only after the effect succeeds: a crash
+	// mid-effect causes a deliberate re-send on the next entry.
+	AtLeastOnce
+)
+
+// effectKeyPrefix namespaces effect bookkeeping entries in Meta.
+const effectKeyPrefix = ReservedMetaPrefix + "effect:"
+
+// Effect registers an OnEnter side effect with explicit delivery semantics.
+// Completion is tracked in Meta under a reserved "fsm:effect:" key, which is
+// serialized with the instance; once the effect has completed, re-entering
+// the state (including after a restore) does not run it again. Use distinct
+// names for distinct effects.
+func (f *FSM) Effect(state State, name g.String, fn Callback, semantics EffectSemantics) *FSM {
+	key := effectKeyPrefix + name
+
+	return f.OnEnter(state, func(ctx *Context) error {
+		if ctx.Meta.Get(key).IsSome() {
+			return nil
+		}
+
+		if semantics == AtMostOnce {
+			ctx.Meta.Insert(key, true)
+		}
+
+		if err := fn(ctx); err != nil {
+			return err
+		}
+
+		if semantics == AtLeastOnce {
+			ctx.Meta.Insert(key, true)
+		}
+
+		return nil
+	})
+}
+
+// EffectDone reports whether the named effect has been recorded as complete.
+func (f *FSM) EffectDone(name g.String) bool {
+	return f.ctx.Meta.Get(effectKeyPrefix + name).IsSome()
+}
+
+// ResetEffect clears the completion record of the named effect so it runs
+// again on the next entry of its state.
+func (f *FSM) ResetEffect(name g.String) {
+	f.ctx.Meta.Remove(effectKeyPrefix + name)
+}
@@ -0,0 +1,59 @@
+package fsm_test
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/enetx/fsm"
+)
+
+func TestFSM_Effect_AtMostOnce(t *testing.T) {
+	runs := 0
+
+	testFSM := New("a").
+		Transition("a", "go", "b").
+		Transition("b", "back", "a").
+		Effect("b", "welcome_email", func(*Context) error {
+			runs++
+			return errors.New("smtp down")
+		}, AtMostOnce)
+
+	// The failed run still counts: at-most-once never retries.
+	assertError(t, testFSM.Trigger("go"))
+	testFSM.SetState("a")
+	assertNoError(t, testFSM.Trigger("go"))
+	assertEqual(t, runs, 1)
+	assertTrue(t, testFSM.EffectDone("welcome_email"))
+}
+
+func TestFSM_Effect_AtLeastOnce(t *testing.T) {
+	runs := 0
+	fail := true
+
+	testFSM := New("a").
+		Transition("a", "go", "b").
+		Transition("b", "back", "a").
+		Effect("b", "invoice", func(*Context) error {
+			runs++
+			if fail {
+				return errors.New("flaky")
+			}
+
+			return nil
+		}, AtLeastOnce)
+
+	assertError(t, testFSM.Trigger("go"))
+	assertFalse(t, testFSM.EffectDone("invoice"))
+
+	// The failure was not recorded, so the effect is re-sent on re-entry.
+	fail = false
+	testFSM.SetState("a")
+	assertNoError(t, testFSM.Trigger("go"))
+	assertEqual(t, runs, 2)
+	assertTrue(t, testFSM.EffectDone("invoice"))
+
+	// Once complete, further entries are skipped.
+	assertNoError(t, testFSM.Trigger("back"))
+	assertNoError(t, testFSM.Trigger("go"))
+	assertEqual(t, runs, 2)
+}
@@ -1,6 +1,26 @@
 package fsm
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+
+	"github.com/enetx/g"
+)
+
+var (
+	// ErrNothingToUndo is returned by Undo when the history holds no state
+	// prior to the current one.
+	ErrNothingToUndo = errors.New("fsm: nothing to undo")
+	// ErrNothingToRedo is returned by Redo when no state has been undone.
+	ErrNothingToRedo = errors.New("fsm: nothing to redo")
+	// ErrRateLimited is returned by Trigger when the configured rate limiter
+	// rejects the attempt. No guards or callbacks run in that case.
+	ErrRateLimited = errors.New("fsm: trigger rate limited")
+	// ErrAuditDisabled is returned by At when the in-memory audit log
+	// required for time-travel reconstruction has not been enabled with
+	// WithAuditLog.
+	ErrAuditDisabled = errors.New("fsm: audit log not enabled")
+)
 
 // ErrAmbiguousTransition is returned when a trigger event results in more than one
 // valid transition. This typically happens due to a configuration error where multiple
@@ -40,6 +60,26 @@ func (e *ErrCallback) Error() string {
 // allowing the use of errors.Is and errors.As to inspect the wrapped error.
 func (e *ErrCallback) Unwrap() error { return e.Err }
 
+// ErrUnknownCheckpoint is returned by Rollback when no checkpoint with the
+// given name has been taken.
+type ErrUnknownCheckpoint struct {
+	Name g.String
+}
+
+func (e *ErrUnknownCheckpoint) Error() string {
+	return fmt.Sprintf("fsm: unknown checkpoint %q", e.Name)
+}
+
+// ErrReservedMetaKey is returned by Context.SetMeta when the key lies in the
+// library's reserved "fsm:" namespace.
+type ErrReservedMetaKey struct {
+	Key g.String
+}
+
+func (e *ErrReservedMetaKey) Error() string {
+	return fmt.Sprintf("fsm: meta key %q is reserved for library bookkeeping", e.Key)
+}
+
 // ErrInvalidTransition is returned when no matching transition is found for the given event
 // from the current state.
 type ErrInvalidTransition struct {
@@ -51,6 +91,72 @@ func (e *ErrInvalidTransition) Error() string {
 	return fmt.Sprintf("fsm: no matching transition for event %q from state %q", e.Event, e.From)
 }
 
+// ErrConflict is returned by SaveTo when the stored snapshot was updated by
+// another writer since this machine last synchronized with the store, and no
+// ConflictResolver is registered.
+type ErrConflict struct {
+	ID            g.String
+	LocalVersion  uint64
+	RemoteVersion uint64
+}
+
+func (e *ErrConflict) Error() string {
+	return fmt.Sprintf("fsm: conflicting write for instance %q: local version %d, stored version %d",
+		e.ID, e.LocalVersion, e.RemoteVersion)
+}
+
+// ErrInstanceNotFound is returned by Store implementations when no instance
+// with the given ID has been saved.
+type ErrInstanceNotFound struct {
+	ID g.String
+}
+
+func (e *ErrInstanceNotFound) Error() string {
+	return fmt.Sprintf("fsm: instance %q not found in store", e.ID)
+}
+
+// ErrMissingField is returned when a serialized snapshot lacks a required
+// field, e.g. a "{}" payload with no current state. This gives interop code
+// a typed error to branch on instead of a confusing unknown-state failure.
+type ErrMissingField struct {
+	Field string
+}
+
+func (e *ErrMissingField) Error() string {
+	return fmt.Sprintf("fsm: snapshot is missing required field %q", e.Field)
+}
+
+// ErrReachability is returned by Validate when a declared MustReach or
+// MustNotReach expectation is violated by the transition graph.
+type ErrReachability struct {
+	From      State
+	Target    State
+	MustReach bool
+}
+
+func (e *ErrReachability) Error() string {
+	if e.MustReach {
+		return fmt.Sprintf("fsm: state %q is not reachable from %q", e.Target, e.From)
+	}
+
+	return fmt.Sprintf("fsm: state %q is reachable from %q", e.Target, e.From)
+}
+
+// ErrUnserializableValue is returned by MarshalJSON when a context value
+// (e.g. a channel, func, or context.CancelFunc stored in Data or Meta)
+// cannot be serialized. It identifies the offending key so the caller can
+// fix the value instead of debugging an opaque json error.
+type ErrUnserializableValue struct {
+	// Section is the context map holding the value: "data" or "meta".
+	Section string
+	Key     g.String
+	Type    string
+}
+
+func (e *ErrUnserializableValue) Error() string {
+	return fmt.Sprintf("fsm: %s key %q holds unserializable value of type %s", e.Section, e.Key, e.Type)
+}
+
 // ErrUnknownState is returned when attempting to unmarshal a state that has not
 // been defined in the FSM's configuration. This prevents the FSM from entering
 // an invalid, undeclared state.
@@ -0,0 +1,117 @@
+package fsm
+
+import (
+	"errors"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/enetx/g"
+)
+
+// Interface compliance check.
+var _ Store = (*FileStore)(nil)
+
+// FileStore is a Store that writes each instance to a JSON file inside a
+// directory. Writes go to a temporary file that is fsynced and atomically
+// renamed into place, so a crash never leaves a half-written snapshot. It
+// suits CLIs and small daemons that need durability without a database.
+type FileStore struct {
+	dir g.String
+}
+
+// NewFileStore returns a FileStore rooted at dir, creating the directory if
+// needed. Instance IDs are path-escaped, so any ID is a safe file name.
+func NewFileStore(dir g.String) (*FileStore, error) {
+	if err := os.MkdirAll(dir.Std(), 0o755); err != nil {
+		return nil, err
+	}
+
+	return &FileStore{dir: dir}, nil
+}
+
+// path returns the file path for an instance ID.
+func (s *FileStore) path(id g.String) string {
+	return filepath.Join(s.dir.Std(), url.PathEscape(id.Std())+".json")
+}
+
+// Save atomically writes the serialized instance under id.
+func (s *FileStore) Save(id g.String, data []byte) error {
+	tmp, err := os.CreateTemp(s.dir.Std(), ".fsm-*.tmp")
+	if err != nil {
+		return err
+	}
+
+	if _, err := tmp.Write(data); err == nil {
+		err = tmp.Sync()
+	}
+
+	if err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.path(id))
+}
+
+// Load reads the serialized instance stored under id. It returns
+// *ErrInstanceNotFound when no file exists for the id.
+func (s *FileStore) Load(id g.String) ([]byte, error) {
+	data, err := os.ReadFile(s.path(id))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, &ErrInstanceNotFound{ID: id}
+	}
+
+	return data, err
+}
+
+// Delete removes the instance stored under id. Deleting an unknown id is
+// not an error.
+func (s *FileStore) Delete(id g.String) error {
+	err := os.Remove(s.path(id))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
+
+// LoadAll scans the directory and returns every stored instance keyed by ID.
+func (s *FileStore) LoadAll() (g.Map[g.String, []byte], error) {
+	entries, err := os.ReadDir(s.dir.Std())
+	if err != nil {
+		return nil, err
+	}
+
+	instances := g.NewMap[g.String, []byte]()
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+
+		id, err := url.PathUnescape(strings.TrimSuffix(name, ".json"))
+		if err != nil {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(s.dir.Std(), name))
+		if err != nil {
+			return nil, err
+		}
+
+		instances.Insert(g.String(id), data)
+	}
+
+	return instances, nil
+}
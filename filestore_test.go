@@ -0,0 +1,33 @@
+package fsm_test
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/enetx/fsm"
+	"github.com/enetx/g"
+)
+
+func TestFileStore(t *testing.T) {
+	store, err := NewFileStore(g.String(t.TempDir()))
+	assertNoError(t, err)
+
+	assertNoError(t, store.Save("user/1", []byte(`{"current":"a"}`)))
+	assertNoError(t, store.Save("user/2", []byte(`{"current":"b"}`)))
+
+	data, err := store.Load("user/1")
+	assertNoError(t, err)
+	assertEqual(t, string(data), `{"current":"a"}`)
+
+	all, err := store.LoadAll()
+	assertNoError(t, err)
+	assertEqual(t, all.Len(), 2)
+	assertTrue(t, all.Contains("user/2"))
+
+	assertNoError(t, store.Delete("user/1"))
+	assertNoError(t, store.Delete("user/1"))
+
+	var notFound *ErrInstanceNotFound
+	_, err = store.Load("user/1")
+	assertTrue(t, errors.As(err, &notFound))
+}
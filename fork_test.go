@@ -0,0 +1,34 @@
+package fsm_test
+
+import (
+	"testing"
+
+	. "github.com/enetx/fsm"
+)
+
+func TestFSM_Fork(t *testing.T) {
+	testFSM := newOrderFSM()
+	testFSM.Context().Data.Insert("amount", 100)
+	assertNoError(t, testFSM.Trigger("pay"))
+
+	forked := testFSM.Fork()
+
+	assertEqual(t, forked.Current(), State("paid"))
+	assertEqual(t, forked.History().Len(), testFSM.History().Len())
+	assertEqual(t, forked.Context().Data.Get("amount").Some().(int), 100)
+
+	// The branches diverge independently.
+	assertNoError(t, forked.Trigger("ship"))
+	forked.Context().Data.Insert("amount", 200)
+
+	assertEqual(t, testFSM.Current(), State("paid"))
+	assertEqual(t, testFSM.Context().Data.Get("amount").Some().(int), 100)
+}
+
+func TestFSM_Fork_VersusClone(t *testing.T) {
+	testFSM := newOrderFSM()
+	assertNoError(t, testFSM.Trigger("pay"))
+
+	assertEqual(t, testFSM.Clone().Current(), State("pending"))
+	assertEqual(t, testFSM.Fork().Current(), State("paid"))
+}
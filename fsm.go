@@ -23,22 +23,113 @@ func New(initial State) *FSM {
 		onEnter:      g.NewMap[State, g.Slice[Callback]](),
 		onExit:       g.NewMap[State, g.Slice[Callback]](),
 		onTransition: g.NewSlice[TransitionHook](),
-		ctx:          newContext(initial),
+		checkpoints:  g.NewMap[g.String, FSMState](),
+		codecs:       g.NewMap[g.String, ValueCodec](),
+		clock:        realClock{},
+		ctx:          newContext(initial, realClock{}),
 	}
 }
 
 // Clone creates a new FSM instance with the same configuration but a fresh state.
 func (f *FSM) Clone() *FSM {
-	return &FSM{
-		initial:      f.initial,
-		current:      f.initial,
-		history:      g.Slice[State]{f.initial},
-		transitions:  f.transitions,
-		onEnter:      f.onEnter,
-		onExit:       f.onExit,
-		onTransition: f.onTransition,
-		ctx:          newContext(f.initial),
+	cloned := &FSM{
+		initial:       f.initial,
+		current:       f.initial,
+		history:       g.Slice[State]{f.initial},
+		transitions:   f.transitions,
+		onEnter:       f.onEnter,
+		onExit:        f.onExit,
+		onTransition:  f.onTransition,
+		checkpoints:   g.NewMap[g.String, FSMState](),
+		codecs:        f.codecs,
+		notifications: f.notifications,
+		config:        f.config,
+		clock:         f.clock,
+		ctx:           newContext(f.initial, f.clock),
+	}
+
+	cloned.ctx.Config = f.config
+
+	return cloned
+}
+
+// CloneDeep creates a new FSM like Clone, but with its own copies of the
+// transition table and callback registries. Clone shares those maps by
+// reference, so adding a transition or callback to a plain clone also
+// mutates the template and every sibling; a deep clone is fully isolated
+// and can be customized per instance.
+func (f *FSM) CloneDeep() *FSM {
+	cloned := f.Clone()
+
+	transitions := g.NewMap[State, g.Slice[transition]]()
+	for state, ts := range f.transitions.Iter() {
+		transitions.Insert(state, ts.Clone())
+	}
+
+	onEnter := g.NewMap[State, g.Slice[Callback]]()
+	for state, cbs := range f.onEnter.Iter() {
+		onEnter.Insert(state, cbs.Clone())
+	}
+
+	onExit := g.NewMap[State, g.Slice[Callback]]()
+	for state, cbs := range f.onExit.Iter() {
+		onExit.Insert(state, cbs.Clone())
 	}
+
+	cloned.transitions = transitions
+	cloned.onEnter = onEnter
+	cloned.onExit = onExit
+	cloned.onTransition = f.onTransition.Clone()
+	cloned.codecs = f.codecs.Clone()
+	cloned.notifications = f.notifications.Clone()
+
+	return cloned
+}
+
+// Fork returns a copy of the machine including its runtime state: current
+// state, history, checkpoints and a deep copy of the context Data and Meta
+// maps, so a running workflow can be branched for what-if evaluation or A/B
+// paths without disturbing the original. The definition (transitions and
+// callbacks) stays shared with the original, as do any configured audit and
+// event sinks. Compare Clone, which resets to the initial state with an
+// empty context.
+func (f *FSM) Fork() *FSM {
+	forked := f.Clone()
+	forked.current = f.current
+	forked.history = f.history.Clone()
+	forked.future = f.future.Clone()
+	forked.checkpoints = f.checkpoints.Clone()
+	forked.ctx.State = f.ctx.State
+	forked.ctx.Data = f.ctx.Data.Clone()
+	forked.ctx.Meta = f.ctx.Meta.Clone()
+	forked.id = f.id
+	forked.actor = f.actor
+	forked.auditEnabled = f.auditEnabled
+	forked.auditSeq = f.auditSeq
+	forked.auditLog = f.auditLog.Clone()
+	forked.auditSinks = f.auditSinks.Clone()
+	forked.eventSink = f.eventSink
+	forked.eventSeq = f.eventSeq
+	forked.version = f.version
+
+	return forked
+}
+
+// WithID assigns an identifier to this machine instance. The ID appears in
+// audit entries and is useful when many instances share one definition.
+func (f *FSM) WithID(id g.String) *FSM {
+	f.id = id
+	return f
+}
+
+// ID returns the instance identifier assigned with WithID.
+func (f *FSM) ID() g.String { return f.id }
+
+// WithActor records who is driving the machine. The actor appears in audit
+// entries for every transition committed until the next WithActor call.
+func (f *FSM) WithActor(actor g.String) *FSM {
+	f.actor = actor
+	return f
 }
 
 // Sync wraps the FSM in a concurrent-safe shell.
@@ -50,14 +141,21 @@ func (f *FSM) Context() *Context { return f.ctx }
 // Current returns the FSM's current state.
 func (f *FSM) Current() State { return f.current }
 
+// Version returns the instance's monotonically increasing version. It is
+// bumped on every committed transition, serialized with the state, and never
+// reset, so stores can implement compare-and-set to detect concurrent writes.
+func (f *FSM) Version() uint64 { return f.version }
+
 // History returns a copy of the list of previously visited states.
 func (f *FSM) History() g.Slice[State] { return f.history.Clone() }
 
 // Reset resets the FSM to its initial state and clears all context data.
 func (f *FSM) Reset() {
 	f.current = f.initial
-	f.ctx = newContext(f.initial)
+	f.ctx = newContext(f.initial, f.clock)
+	f.ctx.Config = f.config
 	f.history = g.Slice[State]{f.initial}
+	f.future = nil
 }
 
 // SetState manually sets the current state, without triggering any callbacks or guards.
@@ -133,12 +231,21 @@ func (f *FSM) OnTransition(hook TransitionHook) *FSM {
 // It accepts an optional single 'input' argument to pass data to guards and callbacks.
 // This input is only valid for the duration of this specific trigger cycle.
 func (f *FSM) Trigger(event Event, input ...any) error {
+	if f.limiter != nil && !f.limiter.Allow() {
+		return ErrRateLimited
+	}
+
 	if len(input) > 0 {
 		f.ctx.Input = input[0]
 	} else {
 		f.ctx.Input = nil
 	}
 
+	var auditBefore g.Map[g.String, any]
+	if f.auditActive() {
+		auditBefore = f.ctx.Data.Iter().Collect()
+	}
+
 	transitions := f.transitions.Get(f.current)
 	if transitions.IsNone() {
 		return &ErrInvalidTransition{From: f.current, Event: event}
@@ -146,7 +253,7 @@ func (f *FSM) Trigger(event Event, input ...any) error {
 
 	matched := transitions.Some().
 		Iter().
-		Exclude(func(t transition) bool { return t.event != event || (t.guard != nil && !t.guard(f.ctx)) }).
+		Exclude(func(t transition) bool { return t.event != event || !f.evalGuard(t.guard, event) }).
 		Collect()
 
 	if matched.IsEmpty() {
@@ -201,8 +308,12 @@ func (f *FSM) Trigger(event Event, input ...any) error {
 
 	f.current = nextState
 	f.history.Push(nextState)
+	f.future = nil
+	f.version++
+	f.recordAudit(previousState, nextState, event, auditBefore)
+	f.dispatchNotifications(previousState, nextState, event)
 
-	return nil
+	return f.appendEvent(previousState, nextState, event)
 }
 
 // executeCallback safely executes a callback, recovering from panics.
@@ -2,11 +2,16 @@ module github.com/enetx/fsm
 
 go 1.24.0
 
-require github.com/enetx/g v1.0.205
+require (
+	github.com/enetx/g v1.0.205
+	go.etcd.io/bbolt v1.3.11
+	google.golang.org/protobuf v1.36.5
+)
 
 require (
 	github.com/andybalholm/brotli v1.2.0 // indirect
 	github.com/enetx/iter v0.0.0-20250912135656-f1583323588f // indirect
 	github.com/klauspost/compress v1.18.3 // indirect
+	golang.org/x/sys v0.30.0 // indirect
 	golang.org/x/text v0.33.0 // indirect
 )
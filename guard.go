@@ -0,0 +1,54 @@
+package fsm
+
+import "time"
+
+// WithGuardBudget enables guard sandboxing with a soft execution budget.
+// When set, every guard is run with panic recovery (a panicking guard is
+// treated as returning false) and its execution time is measured; guards
+// that exceed the budget are reported to all registered OnSlowGuard hooks.
+// A zero duration disables sandboxing (the default).
+func (f *FSM) WithGuardBudget(d time.Duration) *FSM {
+	f.guardBudget = d
+	return f
+}
+
+// OnSlowGuard registers a hook invoked whenever a guard exceeds the budget
+// configured via WithGuardBudget. Hooks run synchronously inside Trigger.
+func (f *FSM) OnSlowGuard(hook SlowGuardHook) *FSM {
+	f.onSlowGuard.Push(hook)
+	return f
+}
+
+// evalGuard evaluates a transition guard. A nil guard always allows the
+// transition. When a guard budget is configured the guard is sandboxed:
+// panics are recovered and treated as a rejection, and slow executions are
+// reported to the OnSlowGuard hooks.
+func (f *FSM) evalGuard(guard GuardFunc, event Event) bool {
+	if guard == nil {
+		return true
+	}
+
+	if f.guardBudget == 0 {
+		return guard(f.ctx)
+	}
+
+	start := f.clock.Now()
+
+	allowed := func() (ok bool) {
+		defer func() {
+			if r := recover(); r != nil {
+				ok = false
+			}
+		}()
+
+		return guard(f.ctx)
+	}()
+
+	if elapsed := f.clock.Now().Sub(start); elapsed > f.guardBudget {
+		for hook := range f.onSlowGuard.Iter() {
+			hook(f.current, event, elapsed)
+		}
+	}
+
+	return allowed
+}
@@ -0,0 +1,41 @@
+package fsm_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/enetx/fsm"
+)
+
+func TestFSM_GuardBudget_PanicRecovered(t *testing.T) {
+	testFSM := New("idle").
+		WithGuardBudget(time.Second).
+		TransitionWhen("idle", "start", "running", func(*Context) bool { panic("boom") })
+
+	assertError(t, testFSM.Trigger("start"))
+	assertEqual(t, testFSM.Current(), State("idle"))
+}
+
+func TestFSM_GuardBudget_SlowGuardHook(t *testing.T) {
+	var slow bool
+
+	clock := &stubClock{now: time.Now()}
+
+	testFSM := New("idle").
+		WithClock(clock).
+		WithGuardBudget(time.Millisecond).
+		OnSlowGuard(func(State, Event, time.Duration) { slow = true }).
+		TransitionWhen("idle", "start", "running", func(*Context) bool {
+			clock.now = clock.now.Add(time.Second)
+			return true
+		})
+
+	assertNoError(t, testFSM.Trigger("start"))
+	assertTrue(t, slow)
+	assertEqual(t, testFSM.Current(), State("running"))
+}
+
+// stubClock is a manually advanced Clock for tests.
+type stubClock struct{ now time.Time }
+
+func (c *stubClock) Now() time.Time { return c.now }
@@ -0,0 +1,138 @@
+package fsm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/enetx/g"
+)
+
+// EventRecord is one entry of an append-only event log. Every successful
+// Trigger produces one record, allowing an FSM's runtime state to be rebuilt
+// later by replaying the log.
+type EventRecord struct {
+	Seq   uint64    `json:"seq"`
+	From  State     `json:"from"`
+	To    State     `json:"to"`
+	Event Event     `json:"event"`
+	Time  time.Time `json:"time"`
+}
+
+// EventSink receives event records appended by the FSM. Implementations can
+// write to memory, files, or a message bus.
+type EventSink interface {
+	Append(EventRecord) error
+}
+
+// MemoryEventLog is an EventSink that keeps records in memory.
+// It is the simplest way to event-source an FSM within a single process.
+type MemoryEventLog struct {
+	records g.Slice[EventRecord]
+}
+
+// Append implements the EventSink interface.
+func (l *MemoryEventLog) Append(record EventRecord) error {
+	l.records.Push(record)
+	return nil
+}
+
+// Records returns a copy of all appended records.
+func (l *MemoryEventLog) Records() g.Slice[EventRecord] { return l.records.Clone() }
+
+// WithEventLog enables event-sourcing mode: every successful Trigger is
+// appended to the sink. If the sink fails, the transition stays committed and
+// Trigger returns the append error.
+func (f *FSM) WithEventLog(sink EventSink) *FSM {
+	f.eventSink = sink
+	return f
+}
+
+// Replay resets the FSM and rebuilds its runtime state by re-running the
+// given transitions in order. Callbacks, hooks and guards are suppressed
+// during replay; only the state, history and event log sequence advance.
+func (f *FSM) Replay(events g.Slice[EventRecord]) error {
+	f.Reset()
+	return f.replay(events)
+}
+
+// replay applies event records on top of the current state with callbacks,
+// hooks and guards suppressed. It does not reset the machine first.
+func (f *FSM) replay(events g.Slice[EventRecord]) error {
+	for record := range events.Iter() {
+		applied := false
+
+		if transitions := f.transitions.Get(f.current); transitions.IsSome() {
+			for t := range transitions.Some().Iter() {
+				if t.event != record.Event || (record.To != "" && t.to != record.To) {
+					continue
+				}
+
+				f.current = t.to
+				f.history.Push(t.to)
+				f.ctx.State = t.to
+				f.version++
+				applied = true
+
+				break
+			}
+		}
+
+		if !applied {
+			return fmt.Errorf("fsm: replay failed at seq %d: %w",
+				record.Seq, &ErrInvalidTransition{From: f.current, Event: record.Event})
+		}
+
+		if record.Seq > f.eventSeq {
+			f.eventSeq = record.Seq
+		}
+	}
+
+	return nil
+}
+
+// Restore applies a snapshot and then replays an optional tail of event
+// records on top of it. Combined with periodic snapshots this enables log
+// compaction: persist the latest FSMState plus only the events appended
+// since, instead of replaying an instance's full history at startup.
+// Callbacks, hooks and guards are suppressed while the tail is replayed.
+func (f *FSM) Restore(state FSMState, tail ...EventRecord) error {
+	states := f.States()
+	for _, checkpoint := range state.Checkpoints {
+		if !states.Contains(checkpoint.Current) {
+			return &ErrUnknownState{State: checkpoint.Current}
+		}
+	}
+
+	if err := f.restore(state); err != nil {
+		return err
+	}
+
+	if state.Checkpoints != nil {
+		f.checkpoints = state.Checkpoints.Clone()
+	}
+
+	return f.replay(tail)
+}
+
+// appendEvent writes a committed transition to the event sink, if configured.
+func (f *FSM) appendEvent(from, to State, event Event) error {
+	if f.eventSink == nil {
+		return nil
+	}
+
+	f.eventSeq++
+
+	record := EventRecord{
+		Seq:   f.eventSeq,
+		From:  from,
+		To:    to,
+		Event: event,
+		Time:  f.clock.Now(),
+	}
+
+	if err := f.eventSink.Append(record); err != nil {
+		return fmt.Errorf("fsm: event log append failed: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package fsm_test
+
+import (
+	"testing"
+
+	. "github.com/enetx/fsm"
+)
+
+func newOrderFSM() *FSM {
+	return New("pending").
+		Transition("pending", "pay", "paid").
+		Transition("paid", "ship", "shipped")
+}
+
+func TestFSM_EventLogReplay(t *testing.T) {
+	log := new(MemoryEventLog)
+
+	entered := 0
+	source := newOrderFSM().
+		WithEventLog(log).
+		OnEnter("paid", func(*Context) error { entered++; return nil })
+
+	assertNoError(t, source.Trigger("pay"))
+	assertNoError(t, source.Trigger("ship"))
+	assertEqual(t, log.Records().Len(), 2)
+	assertEqual(t, entered, 1)
+
+	rebuilt := newOrderFSM().
+		OnEnter("paid", func(*Context) error { entered++; return nil })
+
+	assertNoError(t, rebuilt.Replay(log.Records()))
+	assertEqual(t, rebuilt.Current(), State("shipped"))
+	assertEqual(t, rebuilt.History().Len(), 3)
+	// Callbacks are suppressed during replay.
+	assertEqual(t, entered, 1)
+}
+
+func TestFSM_Replay_InvalidEvent(t *testing.T) {
+	rebuilt := newOrderFSM()
+	assertError(t, rebuilt.Replay(SliceOfRecords("ship")))
+}
+
+func TestFSM_Restore_SnapshotPlusTail(t *testing.T) {
+	log := new(MemoryEventLog)
+
+	source := newOrderFSM().WithEventLog(log)
+	assertNoError(t, source.Trigger("pay"))
+
+	// Snapshot taken after "pay"; events appended afterwards form the tail.
+	snapshot := FSMState{Current: source.Current(), History: source.History()}
+	assertNoError(t, source.Trigger("ship"))
+	tail := log.Records()[1:]
+
+	fresh := newOrderFSM()
+	assertNoError(t, fresh.Restore(snapshot, tail...))
+	assertEqual(t, fresh.Current(), State("shipped"))
+	assertEqual(t, fresh.History().Len(), 3)
+}
+
+// SliceOfRecords builds minimal event records from event names.
+func SliceOfRecords(events ...Event) []EventRecord {
+	records := make([]EventRecord, 0, len(events))
+	for i, e := range events {
+		records = append(records, EventRecord{Seq: uint64(i + 1), Event: e})
+	}
+
+	return records
+}
@@ -3,6 +3,7 @@ package fsm
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/enetx/g"
 )
@@ -10,47 +11,274 @@ import (
 // FSMState is a serializable representation of the FSM's state.
 // It uses standard map types for robust JSON handling.
 type FSMState struct {
-	Current State                `json:"current"`
-	History g.Slice[State]       `json:"history"`
-	Data    g.Map[g.String, any] `json:"data"`
-	Meta    g.Map[g.String, any] `json:"meta"`
+	Version     uint64                    `json:"version"`
+	Current     State                     `json:"current"`
+	History     g.Slice[State]            `json:"history"`
+	Data        g.Map[g.String, any]      `json:"data"`
+	Meta        g.Map[g.String, any]      `json:"meta"`
+	Checkpoints g.Map[g.String, FSMState] `json:"checkpoints,omitempty"`
 }
 
-// MarshalJSON implements the json.Marshaler interface.
-func (f *FSM) MarshalJSON() ([]byte, error) {
-	state := FSMState{
+// snapshot captures the FSM's runtime state as an FSMState with raw,
+// in-memory context values. Named checkpoints are not included; callers
+// that need them add them explicitly.
+func (f *FSM) snapshot() FSMState {
+	return FSMState{
+		Version: f.version,
 		Current: f.current,
 		History: f.history.Clone(),
 		Data:    f.ctx.Data.Iter().Collect(),
 		Meta:    f.ctx.Meta.Iter().Collect(),
 	}
+}
 
-	return json.Marshal(state)
+// Snapshot returns a structured copy of the FSM's runtime state, including
+// named checkpoints, so stores and tests can work with FSMState directly
+// instead of encoding to bytes and back. Apply it with Restore, which runs
+// the same unknown-state validation as UnmarshalJSON.
+func (f *FSM) Snapshot() FSMState {
+	state := f.snapshot()
+	if !f.checkpoints.IsEmpty() {
+		state.Checkpoints = f.checkpoints.Clone()
+	}
+
+	return state
 }
 
-// UnmarshalJSON implements the json.Unmarshaler interface.
-func (f *FSM) UnmarshalJSON(data []byte) error {
-	var state FSMState
-	if err := json.Unmarshal(data, &state); err != nil {
-		return fmt.Errorf("failed to unmarshal fsm state: %w", err)
+// marshalState prepares a snapshot for serialization: expired TTL entries
+// are dropped, per-key codecs are applied, and registered types are wrapped
+// in typed envelopes.
+func (f *FSM) marshalState() (FSMState, error) {
+	state := f.snapshot()
+	state.Data = pruneExpired(state.Data, f.clock.Now())
+	state.Meta = pruneExpired(state.Meta, f.clock.Now())
+
+	if err := f.encodeCodecs(state.Data); err != nil {
+		return state, err
+	}
+
+	if err := f.encodeCodecs(state.Meta); err != nil {
+		return state, err
+	}
+
+	state.Data = encodeTyped(state.Data)
+	state.Meta = encodeTyped(state.Meta)
+
+	return state, nil
+}
+
+// pruneExpired removes TTL entries whose deadline has passed.
+func pruneExpired(values g.Map[g.String, any], now time.Time) g.Map[g.String, any] {
+	for key, value := range values.Iter() {
+		if expiring, ok := value.(expiringValue); ok && now.After(expiring.Deadline) {
+			values.Remove(key)
+		}
+	}
+
+	return values
+}
+
+// reviveExpiring converts decoded TTL envelopes back into expiringValue
+// wrappers so expiry keeps working after a restore.
+func reviveExpiring(values g.Map[g.String, any]) {
+	for key, value := range values.Iter() {
+		envelope, ok := value.(map[string]any)
+		if !ok || len(envelope) != 2 {
+			continue
+		}
+
+		raw, hasValue := envelope["value"]
+		deadline, hasDeadline := envelope["deadline"].(string)
+
+		if !hasValue || !hasDeadline {
+			continue
+		}
+
+		if parsed, err := time.Parse(time.RFC3339Nano, deadline); err == nil {
+			values.Insert(key, expiringValue{Value: raw, Deadline: parsed})
+		}
 	}
+}
 
+// restore applies a previously captured FSMState to the FSM. It validates
+// that every referenced state is defined before mutating anything.
+func (f *FSM) restore(state FSMState) error {
 	states := f.States()
 	if !states.Contains(state.Current) {
 		return &ErrUnknownState{State: state.Current}
 	}
 
-	for state := range state.History.Iter() {
-		if !states.Contains(state) {
-			return &ErrUnknownState{State: state}
+	for s := range state.History.Iter() {
+		if !states.Contains(s) {
+			return &ErrUnknownState{State: s}
 		}
 	}
 
+	decodeTyped(state.Data)
+	decodeTyped(state.Meta)
+	reviveExpiring(state.Data)
+	reviveExpiring(state.Meta)
+
+	f.version = state.Version
+	f.baseVersion = state.Version
 	f.current = state.Current
-	f.history = state.History
+	f.history = state.History.Clone()
+	f.future = nil
 	f.ctx.State = state.Current
-	f.ctx.Data = state.Data.Safe()
-	f.ctx.Meta = state.Meta.Safe()
+	f.ctx.Data = state.Data.Clone().Safe()
+	f.ctx.Meta = state.Meta.Clone().Safe()
+
+	return nil
+}
+
+// AllowMissingHistory makes UnmarshalJSON accept snapshots without a history
+// by synthesizing one containing only the current state. This eases interop
+// with snapshots produced by other systems that only persist the state name.
+func (f *FSM) AllowMissingHistory() *FSM {
+	f.synthesizeHistory = true
+	return f
+}
+
+// WithStateMigration registers a state-name mapping applied while
+// unmarshaling, so snapshots persisted by an older deployment keep loading
+// after states have been renamed or merged instead of failing with
+// ErrUnknownState. The mapping covers the current state, the history and any
+// checkpoints; calling it again merges into previous mappings.
+func (f *FSM) WithStateMigration(mapping map[State]State) *FSM {
+	if f.migrations == nil {
+		f.migrations = g.NewMap[State, State]()
+	}
+
+	for old, renamed := range mapping {
+		f.migrations.Insert(old, renamed)
+	}
+
+	return f
+}
+
+// migrateStates rewrites renamed states in a decoded snapshot.
+func (f *FSM) migrateStates(state *FSMState) {
+	if f.migrations == nil {
+		return
+	}
+
+	rename := func(s State) State { return f.migrations.Get(s).UnwrapOr(s) }
+
+	state.Current = rename(state.Current)
+	for i, s := range state.History {
+		state.History[i] = rename(s)
+	}
+
+	for name, checkpoint := range state.Checkpoints {
+		f.migrateStates(&checkpoint)
+		state.Checkpoints[name] = checkpoint
+	}
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (f *FSM) MarshalJSON() ([]byte, error) {
+	state, err := f.marshalState()
+	if err != nil {
+		return nil, err
+	}
+
+	if !f.checkpoints.IsEmpty() {
+		state.Checkpoints = f.checkpoints.Clone()
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		if unserializable := findUnserializable(state); unserializable != nil {
+			return nil, unserializable
+		}
+
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// findUnserializable locates the first context value that json cannot
+// encode, so marshal failures identify the offending key.
+func findUnserializable(state FSMState) error {
+	for section, values := range map[string]g.Map[g.String, any]{"data": state.Data, "meta": state.Meta} {
+		for key, value := range values.Iter() {
+			if _, err := json.Marshal(value); err != nil {
+				return &ErrUnserializableValue{
+					Section: section,
+					Key:     key,
+					Type:    fmt.Sprintf("%T", value),
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (f *FSM) UnmarshalJSON(data []byte) error {
+	var state FSMState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return fmt.Errorf("failed to unmarshal fsm state: %w", err)
+	}
+
+	return f.applySnapshot(state)
+}
+
+// RestoreAndEnter unmarshals a persisted snapshot and then invokes the
+// OnEnter callbacks of the restored state, so side effects such as re-armed
+// timers or re-established subscriptions come back with the state. If a
+// callback fails, the machine keeps the restored state and the error is
+// returned wrapped in *ErrCallback.
+func (f *FSM) RestoreAndEnter(data []byte) error {
+	if err := f.UnmarshalJSON(data); err != nil {
+		return err
+	}
+
+	return f.CallEnter(f.current)
+}
+
+// applySnapshot validates and applies a decoded snapshot. It is shared by
+// the JSON and protobuf unmarshaling paths.
+func (f *FSM) applySnapshot(state FSMState) error {
+	f.migrateStates(&state)
+
+	if err := f.decodeCodecs(state.Data); err != nil {
+		return err
+	}
+
+	if err := f.decodeCodecs(state.Meta); err != nil {
+		return err
+	}
+
+	if state.Current == "" {
+		return &ErrMissingField{Field: "current"}
+	}
+
+	if state.History.IsEmpty() {
+		if !f.synthesizeHistory {
+			return &ErrMissingField{Field: "history"}
+		}
+
+		state.History = g.SliceOf(state.Current)
+	}
+
+	states := f.States()
+	for _, checkpoint := range state.Checkpoints {
+		if !states.Contains(checkpoint.Current) {
+			return &ErrUnknownState{State: checkpoint.Current}
+		}
+	}
+
+	if err := f.restore(state); err != nil {
+		return err
+	}
+
+	f.checkpoints = state.Checkpoints
+	if f.checkpoints == nil {
+		f.checkpoints = g.NewMap[g.String, FSMState]()
+	}
 
 	return nil
 }
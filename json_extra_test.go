@@ -0,0 +1,62 @@
+package fsm_test
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/enetx/fsm"
+	"github.com/enetx/g"
+)
+
+func TestFSM_Unmarshal_MissingFields(t *testing.T) {
+	testFSM := New("a").Transition("a", "go", "b")
+
+	var missing *ErrMissingField
+
+	err := testFSM.UnmarshalJSON([]byte(`{}`))
+	assertTrue(t, errors.As(err, &missing))
+	assertEqual(t, missing.Field, "current")
+
+	err = testFSM.UnmarshalJSON([]byte(`{"current":"b"}`))
+	assertTrue(t, errors.As(err, &missing))
+	assertEqual(t, missing.Field, "history")
+}
+
+func TestFSM_Marshal_UnserializableValue(t *testing.T) {
+	testFSM := New("a").Transition("a", "go", "b")
+	testFSM.Context().Data.Insert("done", make(chan struct{}))
+
+	_, err := testFSM.MarshalJSON()
+
+	var unserializable *ErrUnserializableValue
+	assertTrue(t, errors.As(err, &unserializable))
+	assertEqual(t, unserializable.Section, "data")
+	assertEqual(t, unserializable.Key, g.String("done"))
+}
+
+func TestFSM_Version(t *testing.T) {
+	source := New("a").Transition("a", "go", "b").Transition("b", "go", "a")
+
+	assertEqual(t, source.Version(), uint64(0))
+	assertNoError(t, source.Trigger("go"))
+	assertNoError(t, source.Trigger("go"))
+	assertEqual(t, source.Version(), uint64(2))
+
+	data, err := source.MarshalJSON()
+	assertNoError(t, err)
+
+	restored := New("a").Transition("a", "go", "b").Transition("b", "go", "a")
+	assertNoError(t, restored.UnmarshalJSON(data))
+	assertEqual(t, restored.Version(), uint64(2))
+}
+
+func TestFSM_Unmarshal_SynthesizedHistory(t *testing.T) {
+	testFSM := New("a").
+		Transition("a", "go", "b").
+		AllowMissingHistory()
+
+	assertNoError(t, testFSM.UnmarshalJSON([]byte(`{"current":"b"}`)))
+	assertEqual(t, testFSM.Current(), State("b"))
+	assertEqual(t, testFSM.History().Len(), 1)
+	assertEqual(t, testFSM.History()[0], State("b"))
+}
@@ -0,0 +1,129 @@
+package fsm
+
+import (
+	"sync"
+
+	"github.com/enetx/g"
+)
+
+// Manager creates and tracks FSM instances derived from a shared definition,
+// keyed by a string ID such as a user or order ID. Instances are wrapped in
+// SyncFSM, so they are safe to drive from multiple goroutines.
+type Manager struct {
+	definition *FSM
+	instances  *g.MapSafe[g.String, *SyncFSM]
+
+	mu         sync.Mutex
+	persistErr error
+}
+
+// NewManager returns a Manager that derives instances from the given
+// definition. The definition itself is never triggered; each instance is a
+// clone starting at the initial state.
+func NewManager(definition *FSM) *Manager {
+	return &Manager{
+		definition: definition,
+		instances:  g.NewMapSafe[g.String, *SyncFSM](),
+	}
+}
+
+// Get returns the instance with the given ID, if one exists.
+func (m *Manager) Get(id g.String) g.Option[*SyncFSM] { return m.instances.Get(id) }
+
+// GetOrCreate returns the instance with the given ID, creating it from the
+// definition on first access.
+func (m *Manager) GetOrCreate(id g.String) *SyncFSM {
+	return m.instances.Entry(id).OrInsertWith(func() *SyncFSM {
+		instance := m.definition.Clone()
+		instance.id = id
+
+		return instance.Sync()
+	})
+}
+
+// Fork deep-copies a live instance (state, history, context data and
+// journal wiring) under a new ID, so an alternative path can be explored
+// against real session data without affecting the original instance.
+func (m *Manager) Fork(id, newID g.String) (*SyncFSM, error) {
+	source := m.instances.Get(id)
+	if source.IsNone() {
+		return nil, &ErrInstanceNotFound{ID: id}
+	}
+
+	src := source.Some()
+
+	src.mu.RLock()
+	forked := src.fsm.Fork()
+	src.mu.RUnlock()
+
+	forked.id = newID
+	instance := forked.Sync()
+	m.instances.Insert(newID, instance)
+
+	return instance, nil
+}
+
+// HealthReport summarizes a Manager's operational state in a structured form
+// suitable for /healthz and readiness endpoints, so workflow services can
+// expose meaningful readiness rather than just process liveness.
+type HealthReport struct {
+	// Instances is the number of tracked instances.
+	Instances g.Int `json:"instances"`
+	// States counts tracked instances per current state, making stuck
+	// workflows visible at a glance.
+	States g.Map[State, g.Int] `json:"states"`
+	// StoreOK reports whether the last persistence operation succeeded.
+	// It is true when no store is configured.
+	StoreOK bool `json:"store_ok"`
+	// LastPersistError holds the message of the most recent persistence
+	// failure, if any.
+	LastPersistError g.String `json:"last_persist_error,omitempty"`
+}
+
+// Health returns a snapshot of the manager's operational state.
+func (m *Manager) Health() HealthReport {
+	report := HealthReport{
+		Instances: m.instances.Len(),
+		States:    g.NewMap[State, g.Int](),
+		StoreOK:   true,
+	}
+
+	for _, instance := range m.instances.Iter() {
+		state := instance.Current()
+		report.States.Insert(state, report.States.Get(state).UnwrapOrDefault()+1)
+	}
+
+	if err := m.lastPersistError(); err != nil {
+		report.StoreOK = false
+		report.LastPersistError = g.String(err.Error())
+	}
+
+	return report
+}
+
+// lastPersistError returns the most recent persistence failure, if any.
+func (m *Manager) lastPersistError() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.persistErr
+}
+
+// setPersistError records the outcome of the most recent persistence
+// operation.
+func (m *Manager) setPersistError(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.persistErr = err
+}
+
+// Remove drops the instance with the given ID from the manager. It reports
+// whether the instance existed.
+func (m *Manager) Remove(id g.String) bool { return m.instances.Remove(id).IsSome() }
+
+// IDs returns the IDs of all tracked instances.
+func (m *Manager) IDs() g.Slice[g.String] { return m.instances.Keys() }
+
+// Len returns the number of tracked instances.
+func (m *Manager) Len() g.Int { return m.instances.Len() }
@@ -0,0 +1,56 @@
+package fsm_test
+
+import (
+	"testing"
+
+	. "github.com/enetx/fsm"
+)
+
+func TestManager_GetOrCreate(t *testing.T) {
+	manager := NewManager(newOrderFSM())
+
+	first := manager.GetOrCreate("order-1")
+	assertNoError(t, first.Trigger("pay"))
+
+	// Same ID returns the same instance; other IDs are independent.
+	assertEqual(t, manager.GetOrCreate("order-1").Current(), State("paid"))
+	assertEqual(t, manager.GetOrCreate("order-2").Current(), State("pending"))
+	assertEqual(t, manager.Len(), 2)
+	assertTrue(t, manager.Get("order-1").IsSome())
+	assertTrue(t, manager.Get("missing").IsNone())
+}
+
+func TestManager_Health(t *testing.T) {
+	manager := NewManager(newOrderFSM())
+
+	assertNoError(t, manager.GetOrCreate("order-1").Trigger("pay"))
+	manager.GetOrCreate("order-2")
+
+	report := manager.Health()
+	assertEqual(t, report.Instances, 2)
+	assertTrue(t, report.StoreOK)
+	assertEqual(t, report.States.Get("paid").Some(), 1)
+	assertEqual(t, report.States.Get("pending").Some(), 1)
+}
+
+func TestManager_Fork(t *testing.T) {
+	manager := NewManager(newOrderFSM())
+
+	original := manager.GetOrCreate("order-1")
+	assertNoError(t, original.Trigger("pay"))
+	original.Context().Data.Insert("total", 100)
+
+	forked, err := manager.Fork("order-1", "order-1-experiment")
+	assertNoError(t, err)
+	assertEqual(t, forked.Current(), State("paid"))
+	assertEqual(t, forked.Context().Data.Get("total").Some().(int), 100)
+
+	// The fork is isolated from the original.
+	assertNoError(t, forked.Trigger("ship"))
+	forked.Context().Data.Insert("total", 50)
+	assertEqual(t, original.Current(), State("paid"))
+	assertEqual(t, original.Context().Data.Get("total").Some().(int), 100)
+
+	_, err = manager.Fork("missing", "copy")
+	assertError(t, err)
+}
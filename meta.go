@@ -0,0 +1,48 @@
+package fsm
+
+import "github.com/enetx/g"
+
+// ReservedMetaPrefix namespaces the Meta keys the library writes for its own
+// bookkeeping. Current reserved keys:
+//
+//	fsm:effect:<name>           — effect completion markers (see Effect)
+//	fsm:approvals:<from>:<event> — collected quorum approvals (see RequireApprovals)
+//
+// Entries under this prefix are serialized with the rest of Meta so the
+// bookkeeping survives restarts. Use the dedicated accessors (EffectDone,
+// Approvals, ...) to read them; writing under the prefix directly risks
+// corrupting library state, which is why SetMeta rejects such keys.
+const ReservedMetaPrefix g.String = "fsm:"
+
+// IsReservedMetaKey reports whether the key lies in the library's reserved
+// Meta namespace.
+func IsReservedMetaKey(key g.String) bool {
+	return key.StartsWith(ReservedMetaPrefix)
+}
+
+// SetMeta stores a Meta value after checking that the key does not collide
+// with the library's reserved namespace. Prefer it over writing to Meta
+// directly; direct writes are not guarded, for compatibility.
+func (c *Context) SetMeta(key g.String, value any) error {
+	if IsReservedMetaKey(key) {
+		return &ErrReservedMetaKey{Key: key}
+	}
+
+	c.Meta.Insert(key, value)
+
+	return nil
+}
+
+// ReservedMeta returns a copy of the Meta entries under the reserved
+// namespace, for debugging and support tooling.
+func (f *FSM) ReservedMeta() g.Map[g.String, any] {
+	reserved := g.NewMap[g.String, any]()
+
+	for key, value := range f.ctx.Meta.Iter() {
+		if IsReservedMetaKey(key) {
+			reserved.Insert(key, value)
+		}
+	}
+
+	return reserved
+}
@@ -0,0 +1,39 @@
+package fsm_test
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/enetx/fsm"
+)
+
+func TestContext_SetMeta_RejectsReservedKeys(t *testing.T) {
+	testFSM := newOrderFSM()
+
+	err := testFSM.Context().SetMeta("fsm:effect:charge", true)
+	assertError(t, err)
+
+	var reserved *ErrReservedMetaKey
+	assertTrue(t, errors.As(err, &reserved))
+	assertEqual(t, reserved.Key, "fsm:effect:charge")
+
+	assertNoError(t, testFSM.Context().SetMeta("attempts", 3))
+	assertEqual(t, testFSM.Context().Meta.Get("attempts").Some().(int), 3)
+}
+
+func TestFSM_ReservedMeta(t *testing.T) {
+	testFSM := New("draft").RequireApprovals("draft", "publish", "published", 2)
+
+	testFSM.WithActor("alice")
+	assertError(t, testFSM.Trigger("publish"))
+	assertNoError(t, testFSM.Context().SetMeta("attempts", 1))
+
+	reserved := testFSM.ReservedMeta()
+	assertEqual(t, reserved.Len(), 1)
+	assertTrue(t, reserved.Contains("fsm:approvals:draft:publish"))
+}
+
+func TestIsReservedMetaKey(t *testing.T) {
+	assertTrue(t, IsReservedMetaKey("fsm:effect:x"))
+	assertFalse(t, IsReservedMetaKey("attempts"))
+}
@@ -0,0 +1,60 @@
+package fsm_test
+
+import (
+	"testing"
+
+	. "github.com/enetx/fsm"
+)
+
+func TestFSM_WithStateMigration(t *testing.T) {
+	old := New("pending").
+		Transition("pending", "pay", "awaiting_shipment")
+
+	assertNoError(t, old.Trigger("pay"))
+
+	data, err := old.MarshalJSON()
+	assertNoError(t, err)
+
+	// The new deployment renamed awaiting_shipment to paid.
+	renamed := New("pending").
+		Transition("pending", "pay", "paid").
+		WithStateMigration(map[State]State{"awaiting_shipment": "paid"})
+
+	assertNoError(t, renamed.UnmarshalJSON(data))
+	assertEqual(t, renamed.Current(), State("paid"))
+	assertEqual(t, renamed.History().Last().Some(), State("paid"))
+}
+
+func TestFSM_WithStateMigration_Checkpoints(t *testing.T) {
+	old := New("pending").
+		Transition("pending", "pay", "awaiting_shipment")
+
+	old.Checkpoint("start")
+	assertNoError(t, old.Trigger("pay"))
+
+	data, err := old.MarshalJSON()
+	assertNoError(t, err)
+
+	renamed := New("queued").
+		Transition("queued", "pay", "paid").
+		WithStateMigration(map[State]State{
+			"pending":           "queued",
+			"awaiting_shipment": "paid",
+		})
+
+	assertNoError(t, renamed.UnmarshalJSON(data))
+	assertEqual(t, renamed.Current(), State("paid"))
+	assertNoError(t, renamed.Rollback("start"))
+	assertEqual(t, renamed.Current(), State("queued"))
+}
+
+func TestFSM_WithoutStateMigration_Fails(t *testing.T) {
+	old := New("pending").Transition("pending", "pay", "awaiting_shipment")
+	assertNoError(t, old.Trigger("pay"))
+
+	data, err := old.MarshalJSON()
+	assertNoError(t, err)
+
+	renamed := New("pending").Transition("pending", "pay", "paid")
+	assertError(t, renamed.UnmarshalJSON(data))
+}
@@ -0,0 +1,85 @@
+package fsm
+
+import (
+	"time"
+
+	"github.com/enetx/g"
+)
+
+// TransitionSelector matches committed transitions by from/to/event. A
+// zero-value field is a wildcard, so Selector{To: "failed"} matches every
+// transition into "failed" regardless of source state or event.
+type TransitionSelector struct {
+	From  State
+	To    State
+	Event Event
+}
+
+// Matches reports whether the selector covers the given transition.
+func (s TransitionSelector) Matches(from, to State, event Event) bool {
+	return (s.From == "" || s.From == from) &&
+		(s.To == "" || s.To == to) &&
+		(s.Event == "" || s.Event == event)
+}
+
+// TransitionEvent carries the details of a committed transition to notifiers.
+type TransitionEvent struct {
+	ID    g.String  `json:"id,omitempty"`
+	From  State     `json:"from"`
+	To    State     `json:"to"`
+	Event Event     `json:"event"`
+	Time  time.Time `json:"time"`
+}
+
+// Notifier delivers a transition notification to an external channel, e.g. a
+// webhook, an email adapter, or a message bus publisher.
+type Notifier interface {
+	Notify(TransitionEvent) error
+}
+
+// NotifierFunc adapts a plain function to the Notifier interface.
+type NotifierFunc func(TransitionEvent) error
+
+// Notify implements the Notifier interface.
+func (fn NotifierFunc) Notify(event TransitionEvent) error { return fn(event) }
+
+// notification pairs a selector with the channels it fans out to.
+type notification struct {
+	selector  TransitionSelector
+	notifiers g.Slice[Notifier]
+}
+
+// Notify registers notifiers invoked after every committed transition the
+// selector matches, replacing sprawling OnTransition switch statements with a
+// declarative "who gets told when" matrix. Notifiers run after the
+// transition has committed; their errors are ignored, matching audit sinks.
+func (f *FSM) Notify(on TransitionSelector, notifiers ...Notifier) *FSM {
+	f.notifications.Push(notification{selector: on, notifiers: g.SliceOf(notifiers...)})
+	return f
+}
+
+// dispatchNotifications fans a committed transition out to every matching
+// notifier.
+func (f *FSM) dispatchNotifications(from, to State, event Event) {
+	if f.notifications.IsEmpty() {
+		return
+	}
+
+	transition := TransitionEvent{
+		ID:    f.id,
+		From:  from,
+		To:    to,
+		Event: event,
+		Time:  f.clock.Now(),
+	}
+
+	for n := range f.notifications.Iter() {
+		if !n.selector.Matches(from, to, event) {
+			continue
+		}
+
+		for notifier := range n.notifiers.Iter() {
+			_ = notifier.Notify(transition)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package fsm_test
+
+import (
+	"testing"
+
+	. "github.com/enetx/fsm"
+	"github.com/enetx/g"
+)
+
+func TestFSM_Notify(t *testing.T) {
+	var shipped g.Slice[TransitionEvent]
+
+	var all g.Slice[TransitionEvent]
+
+	testFSM := newOrderFSM().
+		Notify(TransitionSelector{To: "shipped"}, NotifierFunc(func(e TransitionEvent) error {
+			shipped.Push(e)
+			return nil
+		})).
+		Notify(TransitionSelector{}, NotifierFunc(func(e TransitionEvent) error {
+			all.Push(e)
+			return nil
+		}))
+
+	assertNoError(t, testFSM.Trigger("pay"))
+	assertNoError(t, testFSM.Trigger("ship"))
+
+	assertEqual(t, all.Len(), 2)
+	assertEqual(t, shipped.Len(), 1)
+	assertEqual(t, shipped[0].From, State("paid"))
+	assertEqual(t, shipped[0].To, State("shipped"))
+	assertEqual(t, shipped[0].Event, Event("ship"))
+}
+
+func TestTransitionSelector_Matches(t *testing.T) {
+	assertTrue(t, TransitionSelector{}.Matches("a", "b", "go"))
+	assertTrue(t, TransitionSelector{From: "a", To: "b", Event: "go"}.Matches("a", "b", "go"))
+	assertFalse(t, TransitionSelector{Event: "stop"}.Matches("a", "b", "go"))
+	assertFalse(t, TransitionSelector{From: "b"}.Matches("a", "b", "go"))
+}
+
+func TestFSM_Notify_OnlyOnCommit(t *testing.T) {
+	var fired g.Slice[TransitionEvent]
+
+	testFSM := newOrderFSM().
+		Notify(TransitionSelector{}, NotifierFunc(func(e TransitionEvent) error {
+			fired.Push(e)
+			return nil
+		}))
+
+	assertError(t, testFSM.Trigger("ship"))
+	assertTrue(t, fired.IsEmpty())
+}
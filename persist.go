@@ -0,0 +1,97 @@
+package fsm
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/enetx/g"
+)
+
+// ConflictResolver decides what to persist when a concurrent write is
+// detected during SaveTo: local is this machine's snapshot, remote is the
+// one found in the store. It returns the snapshot to keep, letting
+// applications merge or pick-latest instead of failing.
+type ConflictResolver func(local, remote FSMState) (FSMState, error)
+
+// OnConflict registers a resolver invoked when SaveTo detects that another
+// writer has updated the stored snapshot since this machine last loaded or
+// saved it. Without a resolver, SaveTo fails with *ErrConflict.
+func (f *FSM) OnConflict(resolver ConflictResolver) *FSM {
+	f.conflictResolver = resolver
+	return f
+}
+
+// SaveTo persists the machine to the store under id using optimistic
+// concurrency. If the stored snapshot changed since this machine last
+// synchronized with the store (two replicas triggering the same instance),
+// the registered ConflictResolver is consulted; its result is applied to
+// this machine and persisted. Without a resolver, SaveTo returns
+// *ErrConflict and persists nothing.
+func (f *FSM) SaveTo(store Store, id g.String) error {
+	local, err := f.marshalState()
+	if err != nil {
+		return err
+	}
+
+	local.Checkpoints = f.checkpoints.Clone()
+
+	stored, err := store.Load(id)
+
+	var notFound *ErrInstanceNotFound
+
+	switch {
+	case errors.As(err, &notFound):
+		// First write for this id.
+	case err != nil:
+		return err
+	default:
+		var remote FSMState
+		if err := json.Unmarshal(stored, &remote); err != nil {
+			return err
+		}
+
+		if remote.Version != f.baseVersion {
+			if f.conflictResolver == nil {
+				return &ErrConflict{ID: id, LocalVersion: local.Version, RemoteVersion: remote.Version}
+			}
+
+			resolved, err := f.conflictResolver(local, remote)
+			if err != nil {
+				return err
+			}
+
+			if err := f.applySnapshot(resolved); err != nil {
+				return err
+			}
+
+			local = resolved
+		}
+	}
+
+	data, err := json.Marshal(local)
+	if err != nil {
+		if unserializable := findUnserializable(local); unserializable != nil {
+			return unserializable
+		}
+
+		return err
+	}
+
+	if err := store.Save(id, data); err != nil {
+		return err
+	}
+
+	f.baseVersion = local.Version
+
+	return nil
+}
+
+// LoadFrom restores the machine from the snapshot stored under id.
+func (f *FSM) LoadFrom(store Store, id g.String) error {
+	data, err := store.Load(id)
+	if err != nil {
+		return err
+	}
+
+	return f.UnmarshalJSON(data)
+}
@@ -0,0 +1,71 @@
+package fsm_test
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/enetx/fsm"
+	"github.com/enetx/g"
+)
+
+func TestFSM_SaveTo_Conflict(t *testing.T) {
+	store, err := NewFileStore(g.String(t.TempDir()))
+	assertNoError(t, err)
+
+	replicaA := newOrderFSM()
+	replicaB := newOrderFSM()
+
+	assertNoError(t, replicaA.Trigger("pay"))
+	assertNoError(t, replicaA.SaveTo(store, "order-1"))
+
+	// Replica B never synchronized with the store, so its write conflicts.
+	assertNoError(t, replicaB.Trigger("pay"))
+
+	var conflict *ErrConflict
+	assertTrue(t, errors.As(replicaB.SaveTo(store, "order-1"), &conflict))
+	assertEqual(t, conflict.ID, g.String("order-1"))
+}
+
+func TestFSM_SaveTo_ConflictResolved(t *testing.T) {
+	store, err := NewFileStore(g.String(t.TempDir()))
+	assertNoError(t, err)
+
+	replicaA := newOrderFSM()
+	assertNoError(t, replicaA.Trigger("pay"))
+	assertNoError(t, replicaA.Trigger("ship"))
+	assertNoError(t, replicaA.SaveTo(store, "order-1"))
+
+	replicaB := newOrderFSM().
+		OnConflict(func(local, remote FSMState) (FSMState, error) {
+			// Pick whichever snapshot advanced further.
+			if remote.Version > local.Version {
+				return remote, nil
+			}
+
+			return local, nil
+		})
+
+	assertNoError(t, replicaB.Trigger("pay"))
+	assertNoError(t, replicaB.SaveTo(store, "order-1"))
+	assertEqual(t, replicaB.Current(), State("shipped"))
+
+	// Subsequent saves from the synchronized replica succeed directly.
+	assertNoError(t, replicaB.SaveTo(store, "order-1"))
+}
+
+func TestFSM_SaveLoadRoundTrip(t *testing.T) {
+	store, err := NewFileStore(g.String(t.TempDir()))
+	assertNoError(t, err)
+
+	source := newOrderFSM()
+	assertNoError(t, source.Trigger("pay"))
+	assertNoError(t, source.SaveTo(store, "order-1"))
+
+	restored := newOrderFSM()
+	assertNoError(t, restored.LoadFrom(store, "order-1"))
+	assertEqual(t, restored.Current(), State("paid"))
+
+	// A loaded machine can keep progressing and saving without conflicts.
+	assertNoError(t, restored.Trigger("ship"))
+	assertNoError(t, restored.SaveTo(store, "order-1"))
+}
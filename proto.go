@@ -0,0 +1,190 @@
+package fsm
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/enetx/g"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Field numbers of the FSMState message, as declared in fsmstate.proto.
+const (
+	protoFieldVersion = 1
+	protoFieldCurrent = 2
+	protoFieldHistory = 3
+	protoFieldData    = 4
+	protoFieldMeta    = 5
+)
+
+// MarshalProto serializes the FSM's state using the protobuf wire format
+// described by fsmstate.proto, so it can be carried inside gRPC/protobuf
+// persistence layers. Context values are JSON-encoded inside the message;
+// entries stored with a TTL are pruned like in MarshalJSON.
+func (f *FSM) MarshalProto() ([]byte, error) {
+	state, err := f.marshalState()
+	if err != nil {
+		return nil, err
+	}
+
+	var out []byte
+	out = protowire.AppendTag(out, protoFieldVersion, protowire.VarintType)
+	out = protowire.AppendVarint(out, state.Version)
+	out = protowire.AppendTag(out, protoFieldCurrent, protowire.BytesType)
+	out = protowire.AppendString(out, string(state.Current))
+
+	for state := range state.History.Iter() {
+		out = protowire.AppendTag(out, protoFieldHistory, protowire.BytesType)
+		out = protowire.AppendString(out, string(state))
+	}
+
+	for _, section := range []struct {
+		field  protowire.Number
+		values g.Map[g.String, any]
+	}{{protoFieldData, state.Data}, {protoFieldMeta, state.Meta}} {
+		for key, value := range section.values.Iter() {
+			encoded, err := json.Marshal(value)
+			if err != nil {
+				return nil, &ErrUnserializableValue{
+					Section: map[protowire.Number]string{protoFieldData: "data", protoFieldMeta: "meta"}[section.field],
+					Key:     key,
+					Type:    fmt.Sprintf("%T", value),
+				}
+			}
+
+			var entry []byte
+			entry = protowire.AppendTag(entry, 1, protowire.BytesType)
+			entry = protowire.AppendString(entry, key.Std())
+			entry = protowire.AppendTag(entry, 2, protowire.BytesType)
+			entry = protowire.AppendBytes(entry, encoded)
+
+			out = protowire.AppendTag(out, section.field, protowire.BytesType)
+			out = protowire.AppendBytes(out, entry)
+		}
+	}
+
+	return out, nil
+}
+
+// UnmarshalProto restores the FSM's state from data produced by MarshalProto.
+// It runs the same validation as UnmarshalJSON, including unknown-state and
+// missing-field checks.
+func (f *FSM) UnmarshalProto(data []byte) error {
+	state := FSMState{
+		Data: g.NewMap[g.String, any](),
+		Meta: g.NewMap[g.String, any](),
+	}
+
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return fmt.Errorf("fsm: invalid protobuf tag: %w", protowire.ParseError(n))
+		}
+
+		data = data[n:]
+
+		switch {
+		case num == protoFieldVersion && typ == protowire.VarintType:
+			version, n := protowire.ConsumeVarint(data)
+			if n < 0 {
+				return fmt.Errorf("fsm: invalid version field: %w", protowire.ParseError(n))
+			}
+
+			state.Version = version
+			data = data[n:]
+		case num == protoFieldCurrent && typ == protowire.BytesType:
+			current, n := protowire.ConsumeString(data)
+			if n < 0 {
+				return fmt.Errorf("fsm: invalid current field: %w", protowire.ParseError(n))
+			}
+
+			state.Current = State(current)
+			data = data[n:]
+		case num == protoFieldHistory && typ == protowire.BytesType:
+			entry, n := protowire.ConsumeString(data)
+			if n < 0 {
+				return fmt.Errorf("fsm: invalid history field: %w", protowire.ParseError(n))
+			}
+
+			state.History.Push(State(entry))
+			data = data[n:]
+		case (num == protoFieldData || num == protoFieldMeta) && typ == protowire.BytesType:
+			entry, n := protowire.ConsumeBytes(data)
+			if n < 0 {
+				return fmt.Errorf("fsm: invalid map field: %w", protowire.ParseError(n))
+			}
+
+			key, value, err := consumeMapEntry(entry)
+			if err != nil {
+				return err
+			}
+
+			if num == protoFieldData {
+				state.Data.Insert(key, value)
+			} else {
+				state.Meta.Insert(key, value)
+			}
+
+			data = data[n:]
+		default:
+			// Skip unknown fields for forward compatibility.
+			n := protowire.ConsumeFieldValue(num, typ, data)
+			if n < 0 {
+				return fmt.Errorf("fsm: invalid field %d: %w", num, protowire.ParseError(n))
+			}
+
+			data = data[n:]
+		}
+	}
+
+	return f.applySnapshot(state)
+}
+
+// consumeMapEntry decodes one map<string, bytes> entry whose value holds a
+// JSON-encoded context value.
+func consumeMapEntry(entry []byte) (g.String, any, error) {
+	var (
+		key   g.String
+		value any
+	)
+
+	for len(entry) > 0 {
+		num, typ, n := protowire.ConsumeTag(entry)
+		if n < 0 {
+			return "", nil, fmt.Errorf("fsm: invalid map entry: %w", protowire.ParseError(n))
+		}
+
+		entry = entry[n:]
+
+		switch {
+		case num == 1 && typ == protowire.BytesType:
+			decoded, n := protowire.ConsumeString(entry)
+			if n < 0 {
+				return "", nil, fmt.Errorf("fsm: invalid map key: %w", protowire.ParseError(n))
+			}
+
+			key = g.String(decoded)
+			entry = entry[n:]
+		case num == 2 && typ == protowire.BytesType:
+			encoded, n := protowire.ConsumeBytes(entry)
+			if n < 0 {
+				return "", nil, fmt.Errorf("fsm: invalid map value: %w", protowire.ParseError(n))
+			}
+
+			if err := json.Unmarshal(encoded, &value); err != nil {
+				return "", nil, fmt.Errorf("fsm: invalid map value for key %q: %w", key, err)
+			}
+
+			entry = entry[n:]
+		default:
+			n := protowire.ConsumeFieldValue(num, typ, entry)
+			if n < 0 {
+				return "", nil, fmt.Errorf("fsm: invalid map entry field: %w", protowire.ParseError(n))
+			}
+
+			entry = entry[n:]
+		}
+	}
+
+	return key, value, nil
+}
@@ -0,0 +1,36 @@
+package fsm_test
+
+import (
+	"testing"
+
+	. "github.com/enetx/fsm"
+)
+
+func TestFSM_ProtoRoundTrip(t *testing.T) {
+	source := newOrderFSM()
+	assertNoError(t, source.Trigger("pay"))
+	source.Context().Data.Insert("total", 100)
+	source.Context().Meta.Insert("attempts", 1)
+
+	data, err := source.MarshalProto()
+	assertNoError(t, err)
+
+	restored := newOrderFSM()
+	assertNoError(t, restored.UnmarshalProto(data))
+	assertEqual(t, restored.Current(), State("paid"))
+	assertEqual(t, restored.Version(), uint64(1))
+	assertEqual(t, restored.History().Len(), 2)
+	assertEqual(t, restored.Context().Data.Get("total").Some().(float64), float64(100))
+	assertEqual(t, restored.Context().Meta.Get("attempts").Some().(float64), float64(1))
+}
+
+func TestFSM_UnmarshalProto_UnknownState(t *testing.T) {
+	source := New("a").Transition("a", "go", "b")
+	assertNoError(t, source.Trigger("go"))
+
+	data, err := source.MarshalProto()
+	assertNoError(t, err)
+
+	other := New("x").Transition("x", "go", "y")
+	assertError(t, other.UnmarshalProto(data))
+}
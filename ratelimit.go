@@ -0,0 +1,71 @@
+package fsm
+
+import (
+	"sync"
+	"time"
+)
+
+// RateLimiter gates trigger attempts. Implementations may limit a single
+// instance or be shared across many machines for a global limit.
+type RateLimiter interface {
+	Allow() bool
+}
+
+// TokenBucket is a RateLimiter refilling at a fixed rate up to a burst
+// capacity. It is safe for concurrent use and reads time through a Clock,
+// so it can be driven by a fake clock in tests.
+type TokenBucket struct {
+	mu     sync.Mutex
+	clock  Clock
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewTokenBucket returns a TokenBucket allowing rate triggers per second
+// with bursts of up to burst triggers.
+func NewTokenBucket(rate float64, burst int) *TokenBucket {
+	return &TokenBucket{
+		clock:  realClock{},
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+	}
+}
+
+// WithClock replaces the bucket's time source.
+func (tb *TokenBucket) WithClock(c Clock) *TokenBucket {
+	tb.clock = c
+	return tb
+}
+
+// Allow implements the RateLimiter interface.
+func (tb *TokenBucket) Allow() bool {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	now := tb.clock.Now()
+
+	if !tb.last.IsZero() {
+		tb.tokens = min(tb.burst, tb.tokens+now.Sub(tb.last).Seconds()*tb.rate)
+	}
+
+	tb.last = now
+
+	if tb.tokens < 1 {
+		return false
+	}
+
+	tb.tokens--
+
+	return true
+}
+
+// WithRateLimiter installs a limiter consulted before every Trigger.
+// Rejected triggers fail fast with ErrRateLimited before any guard or
+// callback runs, containing abusive clients inside the library.
+func (f *FSM) WithRateLimiter(limiter RateLimiter) *FSM {
+	f.limiter = limiter
+	return f
+}
@@ -0,0 +1,29 @@
+package fsm_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/enetx/fsm"
+)
+
+func TestFSM_RateLimiter(t *testing.T) {
+	clock := &stubClock{now: time.Now()}
+
+	testFSM := New("a").
+		WithClock(clock).
+		WithRateLimiter(NewTokenBucket(1, 2).WithClock(clock)).
+		Transition("a", "go", "b").
+		Transition("b", "go", "a")
+
+	assertNoError(t, testFSM.Trigger("go"))
+	assertNoError(t, testFSM.Trigger("go"))
+
+	// Burst exhausted: the third trigger within the same instant is rejected.
+	assertTrue(t, errors.Is(testFSM.Trigger("go"), ErrRateLimited))
+
+	// After a second a token has been refilled.
+	clock.now = clock.now.Add(time.Second)
+	assertNoError(t, testFSM.Trigger("go"))
+}
@@ -0,0 +1,48 @@
+package fsm_test
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/enetx/fsm"
+)
+
+func TestFSM_RestoreAndEnter(t *testing.T) {
+	source := newOrderFSM()
+	assertNoError(t, source.Trigger("pay"))
+
+	data, err := source.MarshalJSON()
+	assertNoError(t, err)
+
+	entered := 0
+
+	restored := newOrderFSM().OnEnter("paid", func(_ *Context) error {
+		entered++
+		return nil
+	})
+
+	assertNoError(t, restored.RestoreAndEnter(data))
+	assertEqual(t, restored.Current(), State("paid"))
+	assertEqual(t, entered, 1)
+}
+
+func TestFSM_RestoreAndEnter_CallbackError(t *testing.T) {
+	source := newOrderFSM()
+	assertNoError(t, source.Trigger("pay"))
+
+	data, err := source.MarshalJSON()
+	assertNoError(t, err)
+
+	restored := newOrderFSM().OnEnter("paid", func(_ *Context) error {
+		return errors.New("re-subscribe failed")
+	})
+
+	err = restored.RestoreAndEnter(data)
+	assertError(t, err)
+
+	var cbErr *ErrCallback
+	assertTrue(t, errors.As(err, &cbErr))
+
+	// The state itself is restored even when the callback fails.
+	assertEqual(t, restored.Current(), State("paid"))
+}
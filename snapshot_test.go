@@ -0,0 +1,43 @@
+package fsm_test
+
+import (
+	"testing"
+
+	. "github.com/enetx/fsm"
+)
+
+func TestFSM_Snapshot_Restore_RoundTrip(t *testing.T) {
+	source := newOrderFSM()
+	source.Context().Data.Insert("amount", 100)
+	source.Checkpoint("start")
+	assertNoError(t, source.Trigger("pay"))
+
+	state := source.Snapshot()
+	assertEqual(t, state.Current, State("paid"))
+	assertEqual(t, state.Version, uint64(1))
+
+	target := newOrderFSM()
+	assertNoError(t, target.Restore(state))
+
+	assertEqual(t, target.Current(), State("paid"))
+	assertEqual(t, target.Context().Data.Get("amount").Some().(int), 100)
+	assertNoError(t, target.Rollback("start"))
+	assertEqual(t, target.Current(), State("pending"))
+}
+
+func TestFSM_Snapshot_IsACopy(t *testing.T) {
+	source := newOrderFSM()
+	source.Context().Data.Insert("amount", 100)
+
+	state := source.Snapshot()
+	state.Data.Insert("amount", 200)
+
+	assertEqual(t, source.Context().Data.Get("amount").Some().(int), 100)
+}
+
+func TestFSM_Restore_UnknownState(t *testing.T) {
+	state := newOrderFSM().Snapshot()
+	state.Current = "nonexistent"
+
+	assertError(t, newOrderFSM().Restore(state))
+}
@@ -0,0 +1,12 @@
+package fsm
+
+import "github.com/enetx/g"
+
+// Store persists serialized FSM instances keyed by ID. Implementations must
+// be safe for concurrent use. Load returns *ErrInstanceNotFound when no
+// instance with the given ID has been saved.
+type Store interface {
+	Save(id g.String, data []byte) error
+	Load(id g.String) ([]byte, error)
+	Delete(id g.String) error
+}
@@ -32,6 +32,15 @@ func (sf *SyncFSM) Context() *Context {
 	return sf.fsm.Context()
 }
 
+// Version is the thread-safe version of FSM.Version.
+// It returns the instance's monotonically increasing version.
+func (sf *SyncFSM) Version() uint64 {
+	sf.mu.RLock()
+	defer sf.mu.RUnlock()
+
+	return sf.fsm.Version()
+}
+
 // SetState is the thread-safe version of FSM.SetState.
 // It forcefully sets the current state, bypassing all callbacks and guards.
 // WARNING: This is a low-level method intended for specific use cases like
@@ -79,6 +88,24 @@ func (sf *SyncFSM) CallEnter(state State) error {
 	return sf.fsm.CallEnter(state)
 }
 
+// Barrier blocks until every trigger that started before the call has
+// completed, then returns. After Barrier returns, reads are guaranteed to
+// observe the effects of all previously started triggers, which lets tests
+// and request handlers rely on read-your-writes semantics without polling.
+func (sf *SyncFSM) Barrier() {
+	sf.mu.Lock()
+	defer sf.mu.Unlock()
+}
+
+// Fork is the thread-safe version of FSM.Fork. The returned machine is a
+// plain *FSM owned by the caller; wrap it with Sync if it will be shared.
+func (sf *SyncFSM) Fork() *FSM {
+	sf.mu.RLock()
+	defer sf.mu.RUnlock()
+
+	return sf.fsm.Fork()
+}
+
 // ToDOT is the thread-safe version of FSM.ToDOT.
 // It generates a DOT language string representation of the FSM for visualization.
 func (sf *SyncFSM) ToDOT() g.String {
@@ -105,3 +132,13 @@ func (sf *SyncFSM) UnmarshalJSON(data []byte) error {
 
 	return sf.fsm.UnmarshalJSON(data)
 }
+
+// RestoreAndEnter is the thread-safe version of FSM.RestoreAndEnter. The
+// restore and the OnEnter callbacks run under one critical section, so no
+// other goroutine observes the restored state before its side effects ran.
+func (sf *SyncFSM) RestoreAndEnter(data []byte) error {
+	sf.mu.Lock()
+	defer sf.mu.Unlock()
+
+	return sf.fsm.RestoreAndEnter(data)
+}
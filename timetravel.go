@@ -0,0 +1,64 @@
+package fsm
+
+import (
+	"fmt"
+
+	"github.com/enetx/g"
+)
+
+// ReadOnly is an immutable view of a machine as it was at some point in its
+// past, reconstructed from the audit log. It exposes the state, the visited
+// history and the context Data as of that moment; mutating the view does not
+// affect the live machine.
+type ReadOnly struct {
+	current State
+	history g.Slice[State]
+	data    g.Map[g.String, any]
+}
+
+// Current returns the state the machine was in at this point.
+func (r ReadOnly) Current() State { return r.current }
+
+// History returns the states visited up to this point.
+func (r ReadOnly) History() g.Slice[State] { return r.history.Clone() }
+
+// Data returns the context data as of this point, rebuilt by applying the
+// audit log's per-transition diffs in order.
+func (r ReadOnly) Data() g.Map[g.String, any] { return r.data.Clone() }
+
+// At reconstructs the machine as it was after the index-th committed
+// transition: At(0) is the machine before any transition, At(1) after the
+// first, and so on. It replays the in-memory audit log's state changes and
+// data diffs, so support engineers can inspect historical context values,
+// not just the state list. The machine must have been built with
+// WithAuditLog; otherwise At fails with ErrAuditDisabled.
+func (f *FSM) At(index int) (ReadOnly, error) {
+	if !f.auditEnabled {
+		return ReadOnly{}, ErrAuditDisabled
+	}
+
+	if index < 0 || g.Int(index) > f.auditLog.Len() {
+		return ReadOnly{}, fmt.Errorf("fsm: transition index %d out of range [0, %d]", index, f.auditLog.Len())
+	}
+
+	view := ReadOnly{
+		current: f.initial,
+		history: g.SliceOf(f.initial),
+		data:    g.NewMap[g.String, any](),
+	}
+
+	for entry := range f.auditLog[:index].Iter() {
+		view.current = entry.To
+		view.history.Push(entry.To)
+
+		for key, value := range entry.DataDiff.Iter() {
+			if value == nil {
+				view.data.Remove(key)
+			} else {
+				view.data.Insert(key, value)
+			}
+		}
+	}
+
+	return view, nil
+}
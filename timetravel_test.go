@@ -0,0 +1,54 @@
+package fsm_test
+
+import (
+	"testing"
+
+	. "github.com/enetx/fsm"
+)
+
+func TestFSM_At(t *testing.T) {
+	testFSM := New("pending").
+		Transition("pending", "pay", "paid").
+		Transition("paid", "ship", "shipped").
+		OnEnter("paid", func(ctx *Context) error {
+			ctx.Data.Insert("amount", 100)
+			return nil
+		}).
+		OnEnter("shipped", func(ctx *Context) error {
+			ctx.Data.Insert("carrier", "dhl")
+			return nil
+		}).
+		WithAuditLog()
+
+	assertNoError(t, testFSM.Trigger("pay"))
+	assertNoError(t, testFSM.Trigger("ship"))
+
+	initial, err := testFSM.At(0)
+	assertNoError(t, err)
+	assertEqual(t, initial.Current(), State("pending"))
+	assertTrue(t, initial.Data().Get("amount").IsNone())
+
+	afterPay, err := testFSM.At(1)
+	assertNoError(t, err)
+	assertEqual(t, afterPay.Current(), State("paid"))
+	assertEqual(t, afterPay.Data().Get("amount").Some(), any(100))
+	assertTrue(t, afterPay.Data().Get("carrier").IsNone())
+
+	afterShip, err := testFSM.At(2)
+	assertNoError(t, err)
+	assertEqual(t, afterShip.Current(), State("shipped"))
+	assertEqual(t, afterShip.Data().Get("carrier").Some(), any("dhl"))
+	assertEqual(t, afterShip.History().Len(), 3)
+}
+
+func TestFSM_At_Errors(t *testing.T) {
+	testFSM := newOrderFSM()
+
+	_, err := testFSM.At(0)
+	assertError(t, err)
+
+	testFSM = newOrderFSM().WithAuditLog()
+
+	_, err = testFSM.At(1)
+	assertError(t, err)
+}
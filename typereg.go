@@ -0,0 +1,93 @@
+package fsm
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/enetx/g"
+)
+
+// typedEnvelope is the serialized form of a context value whose type has
+// been registered with RegisterType.
+type typedEnvelope struct {
+	Type  g.String `json:"$type"`
+	Value any      `json:"$value"`
+}
+
+var (
+	typeNames    = g.NewMapSafe[reflect.Type, g.String]()
+	typeDecoders = g.NewMapSafe[g.String, func(json.RawMessage) (any, error)]()
+)
+
+// RegisterType registers the concrete type T under a stable name so context
+// Data and Meta values of that type survive serialization round-trips with
+// their Go type intact, instead of coming back as float64 or
+// map[string]any. Registration is global and should happen during program
+// initialization.
+func RegisterType[T any](name g.String) {
+	var zero T
+
+	typeNames.Insert(reflect.TypeOf(zero), name)
+	typeDecoders.Insert(name, func(raw json.RawMessage) (any, error) {
+		var value T
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, err
+		}
+
+		return value, nil
+	})
+}
+
+// encodeTyped wraps values of registered types in a typed envelope.
+func encodeTyped(values g.Map[g.String, any]) g.Map[g.String, any] {
+	for key, value := range values.Iter() {
+		if value == nil {
+			continue
+		}
+
+		if name := typeNames.Get(reflect.TypeOf(value)); name.IsSome() {
+			values.Insert(key, typedEnvelope{Type: name.Some(), Value: value})
+		}
+	}
+
+	return values
+}
+
+// decodeTyped restores values wrapped in typed envelopes to their
+// registered Go types. Envelopes of unregistered types are left untouched.
+func decodeTyped(values g.Map[g.String, any]) {
+	for key, value := range values.Iter() {
+		// In-memory snapshots (checkpoints, SaveTo without a round-trip)
+		// hold the envelope struct itself; unwrap it directly.
+		if wrapped, ok := value.(typedEnvelope); ok {
+			values.Insert(key, wrapped.Value)
+			continue
+		}
+
+		envelope, ok := value.(map[string]any)
+		if !ok || len(envelope) != 2 {
+			continue
+		}
+
+		name, hasType := envelope["$type"].(string)
+		raw, hasValue := envelope["$value"]
+
+		if !hasType || !hasValue {
+			continue
+		}
+
+		decoder := typeDecoders.Get(g.String(name))
+		if decoder.IsNone() {
+			continue
+		}
+
+		encoded, err := json.Marshal(raw)
+		if err != nil {
+			continue
+		}
+
+		if decoded, err := decoder.Some()(encoded); err == nil {
+			values.Insert(key, decoded)
+		}
+	}
+}
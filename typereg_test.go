@@ -0,0 +1,45 @@
+package fsm_test
+
+import (
+	"testing"
+
+	. "github.com/enetx/fsm"
+	"github.com/enetx/g"
+)
+
+type money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+func init() {
+	RegisterType[money]("money")
+	RegisterType[g.Int]("g.Int")
+}
+
+func TestFSM_TypePreservingSerialization(t *testing.T) {
+	source := newOrderFSM()
+	source.Context().Data.Insert("price", money{Amount: 999, Currency: "EUR"})
+	source.Context().Data.Insert("user_id", g.Int(42))
+	source.Context().Data.Insert("plain", "string")
+
+	data, err := source.MarshalJSON()
+	assertNoError(t, err)
+
+	restored := newOrderFSM()
+	assertNoError(t, restored.UnmarshalJSON(data))
+
+	assertEqual(t, restored.Context().Data.Get("price").Some().(money), money{Amount: 999, Currency: "EUR"})
+	assertEqual(t, restored.Context().Data.Get("user_id").Some().(g.Int), g.Int(42))
+	assertEqual(t, restored.Context().Data.Get("plain").Some().(string), "string")
+}
+
+func TestFSM_TypePreserving_Checkpoint(t *testing.T) {
+	testFSM := newOrderFSM()
+	testFSM.Context().Data.Insert("price", money{Amount: 1, Currency: "USD"})
+	testFSM.Checkpoint("start")
+	testFSM.Context().Data.Insert("price", money{Amount: 2, Currency: "USD"})
+
+	assertNoError(t, testFSM.Rollback("start"))
+	assertEqual(t, testFSM.Context().Data.Get("price").Some().(money).Amount, int64(1))
+}
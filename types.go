@@ -2,6 +2,7 @@ package fsm
 
 import (
 	"sync"
+	"time"
 
 	"github.com/enetx/g"
 )
@@ -19,6 +20,8 @@ type (
 	// TransitionHook is a global callback called after a transition between states.
 	// It runs after OnExit and before OnEnter.
 	TransitionHook func(from, to State, event Event, ctx *Context) error
+	// SlowGuardHook is called when a guard exceeds the configured execution budget.
+	SlowGuardHook func(from State, event Event, elapsed time.Duration)
 
 	// transition is an internal struct representing a possible path between states.
 	transition struct {
@@ -32,18 +35,58 @@ type (
 		initial      State
 		current      State
 		history      g.Slice[State]
+		future       g.Slice[State]
 		transitions  g.Map[State, g.Slice[transition]]
 		onEnter      g.Map[State, g.Slice[Callback]]
 		onExit       g.Map[State, g.Slice[Callback]]
 		onTransition g.Slice[TransitionHook]
 
-		ctx *Context
+		guardBudget time.Duration
+		onSlowGuard g.Slice[SlowGuardHook]
+
+		id    g.String
+		actor g.String
+
+		auditEnabled bool
+		auditSeq     uint64
+		auditLog     g.Slice[AuditEntry]
+		auditSinks   g.Slice[AuditSink]
+
+		checkpoints g.Map[g.String, FSMState]
+		codecs      g.Map[g.String, ValueCodec]
+
+		eventSink EventSink
+		eventSeq  uint64
+
+		notifications g.Slice[notification]
+
+		assertions g.Slice[reachabilityAssertion]
+
+		synthesizeHistory bool
+		migrations        g.Map[State, State]
+
+		limiter RateLimiter
+
+		version          uint64
+		baseVersion      uint64
+		conflictResolver ConflictResolver
+
+		config ConfigProvider
+
+		clock Clock
+		ctx   *Context
 	}
 
 	// SyncFSM is a thread-safe wrapper around an FSM.
 	// It protects all state-mutating and state-reading operations with a sync.RWMutex,
 	// making it safe for use across multiple goroutines.
 	// All methods on SyncFSM are the thread-safe counterparts to the methods on the base FSM.
+	//
+	// SyncFSM provides a read-your-writes guarantee: once a Trigger call has
+	// returned, any subsequent Current, History or Context read from any
+	// goroutine observes the committed transition. Work that runs outside the
+	// trigger's critical section (e.g. goroutines spawned by callbacks) is not
+	// covered; use Barrier to wait for in-flight triggers to drain.
 	SyncFSM struct {
 		fsm *FSM
 		mu  sync.RWMutex
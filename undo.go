@@ -0,0 +1,50 @@
+package fsm
+
+// Undo reverts the FSM to the previous state in its history. The revert is
+// silent: no guards, callbacks or hooks run and the context data is left
+// untouched. The undone state is pushed onto a redo stack so the step can be
+// reapplied with Redo. Any successful Trigger clears the redo stack.
+func (f *FSM) Undo() error {
+	if f.history.Len().Lte(1) {
+		return ErrNothingToUndo
+	}
+
+	undone := f.history.Pop().Some()
+	f.future.Push(undone)
+
+	f.current = f.history.Last().Some()
+	f.ctx.State = f.current
+
+	return nil
+}
+
+// Redo reapplies the most recently undone state. Like Undo, it is silent:
+// no guards, callbacks or hooks run.
+func (f *FSM) Redo() error {
+	redone := f.future.Pop()
+	if redone.IsNone() {
+		return ErrNothingToRedo
+	}
+
+	f.current = redone.Some()
+	f.history.Push(f.current)
+	f.ctx.State = f.current
+
+	return nil
+}
+
+// Undo is the thread-safe version of FSM.Undo.
+func (sf *SyncFSM) Undo() error {
+	sf.mu.Lock()
+	defer sf.mu.Unlock()
+
+	return sf.fsm.Undo()
+}
+
+// Redo is the thread-safe version of FSM.Redo.
+func (sf *SyncFSM) Redo() error {
+	sf.mu.Lock()
+	defer sf.mu.Unlock()
+
+	return sf.fsm.Redo()
+}
@@ -0,0 +1,37 @@
+package fsm_test
+
+import (
+	"testing"
+
+	. "github.com/enetx/fsm"
+)
+
+func TestFSM_UndoRedo(t *testing.T) {
+	testFSM := New("draft").
+		Transition("draft", "submit", "review").
+		Transition("review", "approve", "published")
+
+	assertEqual(t, testFSM.Undo(), ErrNothingToUndo)
+
+	assertNoError(t, testFSM.Trigger("submit"))
+	assertNoError(t, testFSM.Trigger("approve"))
+	assertEqual(t, testFSM.Current(), State("published"))
+
+	assertNoError(t, testFSM.Undo())
+	assertEqual(t, testFSM.Current(), State("review"))
+
+	assertNoError(t, testFSM.Redo())
+	assertEqual(t, testFSM.Current(), State("published"))
+	assertEqual(t, testFSM.Redo(), ErrNothingToRedo)
+}
+
+func TestFSM_Undo_TriggerClearsRedo(t *testing.T) {
+	testFSM := New("draft").
+		Transition("draft", "submit", "review").
+		Transition("review", "reject", "draft")
+
+	assertNoError(t, testFSM.Trigger("submit"))
+	assertNoError(t, testFSM.Undo())
+	assertNoError(t, testFSM.Trigger("submit"))
+	assertEqual(t, testFSM.Redo(), ErrNothingToRedo)
+}
@@ -0,0 +1,77 @@
+package fsm
+
+import "github.com/enetx/g"
+
+// reachabilityAssertion is a declared expectation about the transition graph,
+// checked by Validate.
+type reachabilityAssertion struct {
+	from   State
+	target State
+	must   bool
+}
+
+// MustReach declares that target must be reachable from each given start
+// state (the initial state when none is given), following transitions and
+// ignoring guards. The expectation is checked by Validate.
+func (f *FSM) MustReach(target State, from ...State) *FSM {
+	return f.assertReachability(target, true, from)
+}
+
+// MustNotReach declares that target must not be reachable from each given
+// start state (the initial state when none is given). The expectation is
+// checked by Validate.
+func (f *FSM) MustNotReach(target State, from ...State) *FSM {
+	return f.assertReachability(target, false, from)
+}
+
+func (f *FSM) assertReachability(target State, must bool, from []State) *FSM {
+	if len(from) == 0 {
+		from = []State{f.initial}
+	}
+
+	for _, start := range from {
+		f.assertions.Push(reachabilityAssertion{from: start, target: target, must: must})
+	}
+
+	return f
+}
+
+// Validate checks all declared expectations against the transition graph.
+// It returns the first violated expectation as a typed error, or nil when
+// the definition satisfies every declaration.
+func (f *FSM) Validate() error {
+	for assertion := range f.assertions.Iter() {
+		reached := f.reachableFrom(assertion.from).Contains(assertion.target)
+		if reached != assertion.must {
+			return &ErrReachability{
+				From:      assertion.from,
+				Target:    assertion.target,
+				MustReach: assertion.must,
+			}
+		}
+	}
+
+	return nil
+}
+
+// reachableFrom returns the set of states reachable from start, including
+// start itself, following transitions and ignoring guards.
+func (f *FSM) reachableFrom(start State) g.Set[State] {
+	visited := g.SetOf(start)
+	queue := g.SliceOf(start)
+
+	for !queue.IsEmpty() {
+		state := queue.Pop().Some()
+
+		if transitions := f.transitions.Get(state); transitions.IsSome() {
+			for t := range transitions.Some().Iter() {
+				if !visited.Contains(t.to) {
+					visited.Insert(t.to)
+					queue.Push(t.to)
+				}
+			}
+		}
+	}
+
+	return visited
+}
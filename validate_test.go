@@ -0,0 +1,32 @@
+package fsm_test
+
+import (
+	"testing"
+
+	. "github.com/enetx/fsm"
+)
+
+func TestFSM_Validate(t *testing.T) {
+	testFSM := New("draft").
+		Transition("draft", "submit", "review").
+		Transition("review", "approve", "published").
+		MustReach("published").
+		MustNotReach("draft", "published")
+
+	assertNoError(t, testFSM.Validate())
+}
+
+func TestFSM_Validate_Violations(t *testing.T) {
+	unreachable := New("draft").
+		Transition("draft", "submit", "review").
+		MustReach("published")
+
+	assertError(t, unreachable.Validate())
+
+	cyclic := New("draft").
+		Transition("draft", "submit", "published").
+		Transition("published", "retract", "draft").
+		MustNotReach("draft", "published")
+
+	assertError(t, cyclic.Validate())
+}